@@ -4,8 +4,11 @@
 package v1
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
@@ -15,6 +18,10 @@ import (
 	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
 )
 
+// ErrDryRun is returned once the preview of a dry run has been written so that no
+// plugin in the chain scaffolds files or saves the project.
+var ErrDryRun = errors.New("dry run complete, no files were written")
+
 type createAPISubcommand struct {
 	config config.Config
 
@@ -23,6 +30,9 @@ type createAPISubcommand struct {
 	workloadConfigPath string
 	cliRootCommandName string
 	workload           workloadv1.WorkloadAPIBuilder
+	userRegions        scaffolds.UserRegions
+
+	dryRun bool
 }
 
 var _ plugin.CreateAPISubcommand = &createAPISubcommand{}
@@ -32,9 +42,17 @@ func (p *createAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdM
 `
 	subcmdMeta.Examples = fmt.Sprintf(`  # Add API attributes defined by a workload config file
   %[1]s create api --workload-config .source-manifests/workload.yaml
+
+  # Preview the files that would be written without modifying the project
+  %[1]s create api --workload-config .source-manifests/workload.yaml --dry-run
 `, cliMeta.CommandName)
 }
 
+func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.dryRun, "dry-run", false,
+		"print the files that would be written, along with a diff against the project, without writing them")
+}
+
 func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 
@@ -55,7 +73,7 @@ func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 	return nil
 }
 
-func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
+func (p *createAPISubcommand) PreScaffold(fs machinery.Filesystem) error {
 	// load the workload config
 	workload, err := workloadv1.ProcessAPIConfig(
 		p.workloadConfigPath,
@@ -72,15 +90,41 @@ func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 
 	p.workload = workload
 
+	// capture the user regions of existing files before any plugin in the chain
+	// overwrites them
+	userRegions, err := scaffolds.SnapshotUserRegions(fs)
+	if err != nil {
+		return fmt.Errorf("unable to preserve user regions, %w", err)
+	}
+
+	p.userRegions = userRegions
+
+	// the preview happens here rather than during scaffolding as the remaining plugins
+	// in the chain write directly to the project and cannot be redirected.  stop the
+	// chain once the preview is written so that no plugin scaffolds files or saves
+	// the project.
+	if p.dryRun {
+		if err := p.preview(fs); err != nil {
+			return err
+		}
+
+		return ErrDryRun
+	}
+
 	return nil
 }
 
 func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
+	return p.scaffold(fs)
+}
+
+func (p *createAPISubcommand) scaffold(fs machinery.Filesystem) error {
 	scaffolder := scaffolds.NewAPIScaffolder(
 		p.config,
 		p.resource,
 		p.workload,
 		p.cliRootCommandName,
+		p.userRegions,
 	)
 	scaffolder.InjectFS(fs)
 
@@ -90,3 +134,23 @@ func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 
 	return nil
 }
+
+// preview scaffolds the api into memory and writes the files that would be changed,
+// along with their diffs, to stdout.
+func (p *createAPISubcommand) preview(fs machinery.Filesystem) error {
+	preview := scaffolds.NewPreview(fs)
+
+	if err := p.scaffold(preview.FS()); err != nil {
+		return err
+	}
+
+	if err := preview.Write(os.Stdout); err != nil {
+		return fmt.Errorf("unable to write preview of api, %w", err)
+	}
+
+	fmt.Fprintln(os.Stdout, "\nNote: only the files scaffolded by operator-builder are previewed.  Files "+
+		"scaffolded by the other plugins of the chain, such as the go/v3 api types and controller "+
+		"scaffolds, are not included.")
+
+	return nil
+}
@@ -20,10 +20,17 @@ import (
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/api/resources"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/cli"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/crd"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/manager"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/samples"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/controller"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/cleanup"
+	intconfig "github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/config"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/dependencies"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/metrics"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/mutate"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/requeue"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/scope"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/tracing"
 	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/test/e2e"
 	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
 )
@@ -37,6 +44,7 @@ var (
 	ErrScaffoldMainUpdater          = errors.New("error updating main.go")
 	ErrScaffoldCRDSample            = errors.New("error scaffolding CRD sample file")
 	ErrScaffoldKustomization        = errors.New("error scaffolding kustomization overlay")
+	ErrScaffoldManagerConfig        = errors.New("error updating controller manager config")
 	ErrScaffoldAPITypes             = errors.New("error scaffolding api types")
 	ErrScaffoldAPIKindInfo          = errors.New("error scaffolding api kind information")
 	ErrScaffoldAPIResources         = errors.New("error scaffolding api resource methods")
@@ -58,6 +66,7 @@ type apiScaffolder struct {
 	boilerplate        string
 	workload           workloadv1.WorkloadAPIBuilder
 	cliRootCommandName string
+	userRegions        UserRegions
 }
 
 // NewAPIScaffolder returns a new Scaffolder for project initialization operations.
@@ -66,18 +75,21 @@ func NewAPIScaffolder(
 	res *resource.Resource,
 	workload workloadv1.WorkloadAPIBuilder,
 	cliRootCommandName string,
+	userRegions UserRegions,
 ) plugins.Scaffolder {
 	return &apiScaffolder{
 		config:             cfg,
 		resource:           res,
 		workload:           workload,
 		cliRootCommandName: cliRootCommandName,
+		userRegions:        userRegions,
 	}
 }
 
-// InjectFS implements cmdutil.Scaffolder.
+// InjectFS implements cmdutil.Scaffolder.  Files are written through a filesystem which
+// preserves user regions so that hand-written code survives a scaffold with --force.
 func (s *apiScaffolder) InjectFS(fs machinery.Filesystem) {
-	s.fs = fs
+	s.fs = machinery.Filesystem{FS: newUserRegionFs(fs.FS, s.userRegions)}
 }
 
 // scaffold implements cmdutil.Scaffolder.
@@ -114,47 +126,109 @@ func (s *apiScaffolder) scaffoldWorkload(
 	// attribute of the scaffolder to be set appropriately so that things like Group,
 	// Version, and Kind are passed from the child component and not the parent
 	// workload.
+	res := s.resource
+
 	if workload.IsComponent() {
+		res = workload.GetComponentResource(
+			s.config.GetDomain(),
+			s.config.GetRepository(),
+			workload.IsClusterScoped(),
+		)
+
 		scaffold = machinery.NewScaffold(s.fs,
 			machinery.WithConfig(s.config),
 			machinery.WithBoilerplate(s.boilerplate),
-			machinery.WithResource(workload.GetComponentResource(
-				s.config.GetDomain(),
-				s.config.GetRepository(),
-				workload.IsClusterScoped(),
-			)),
+			machinery.WithResource(res),
 		)
 	}
 
+	// find the previous versions of the kind.  when the kind already exists at another
+	// version, the version being created becomes the hub which is stored in the cluster.
+	spokes, err := previousVersions(s.config, res)
+	if err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldConversion)
+	}
+
 	// scaffold the workload api.  this generates files within the apis/ folder to include
 	// items such as common resource methods, api type definitions and child resource typed
 	// object definitions.
-	if err := s.scaffoldAPI(scaffold, workload); err != nil {
+	if err := s.scaffoldAPI(scaffold, workload, len(spokes) > 0); err != nil {
 		return fmt.Errorf("%w; %s", err, ErrScaffoldAPIResources)
 	}
 
+	// scaffold the conversion between the previous versions and the version being created.
+	if len(spokes) > 0 {
+		if err := s.scaffoldConversion(scaffold, res, spokes); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrScaffoldConversion)
+		}
+	}
+
+	// scaffold the webhooks.  the webhook server is needed to convert between versions
+	// as well as to default and validate the kind when webhooks are enabled.
+	wireWebhook := workload.HasWebhooks() || len(spokes) > 0
+
+	if wireWebhook {
+		if err := scaffold.Execute(&api.Webhook{Builder: workload}); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrScaffoldWebhooks)
+		}
+	}
+
 	// scaffold the controller.  this generates the main controller logic.
 	if err := scaffold.Execute(
 		&controller.Controller{Builder: workload},
+		&controller.RBAC{Builder: workload},
 		&controller.Phases{PackageName: workload.GetPackageName()},
-		&dependencies.Component{},
+		&dependencies.Component{Builder: workload},
 		&mutate.Component{},
+		&cleanup.Component{},
 		&crd.Kustomization{},
 	); err != nil {
 		return fmt.Errorf("%w; %s", err, ErrScaffoldController)
 	}
 
+	// scaffold the role of the controller of a namespace-scoped workload.  the namespaced roles
+	// are selected in config/rbac/kustomization.yaml in place of the cluster role of the manager
+	// when the manager only watches a set of namespaces.
+	if !workload.IsClusterScoped() {
+		if err := scaffoldNamespacedRBAC(s.fs.FS, scaffold, workload); err != nil {
+			return err
+		}
+	}
+
+	// scaffold the kustomize configuration which serves the webhooks.  this must follow the
+	// crd kustomization so that the patches inserted for the kind are enabled.
+	if workload.HasWebhooks() {
+		if err := scaffoldWebhookConfig(s.fs.FS, s.config, scaffold, res); err != nil {
+			return err
+		}
+	}
+
 	// update controller main entrypoint.  this updates the main.go file with logic related to
 	// creating the new controllers.
 	if err := scaffold.Execute(
 		&templates.MainUpdater{
 			WireResource:   true,
 			WireController: true,
+			WireWebhook:    wireWebhook,
 		},
 	); err != nil {
 		return fmt.Errorf("%w; %s", err, ErrScaffoldMainUpdater)
 	}
 
+	// update the sample controller manager config with the settings of the controller, when the
+	// project has one.
+	if s.config.IsComponentConfig() {
+		updater := &manager.ConfigUpdater{
+			MaxConcurrentReconciles: workload.GetControllerOptions().MaxConcurrentReconciles,
+		}
+
+		if exists, err := afero.Exists(s.fs.FS, updater.GetPath()); err == nil && exists {
+			if err := scaffold.Execute(updater); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrScaffoldManagerConfig)
+			}
+		}
+	}
+
 	// scaffold the custom resource sample files.  this will generate sample manifest files.
 	if err := scaffold.Execute(
 		&samples.CRDSample{
@@ -196,10 +270,11 @@ func (s *apiScaffolder) scaffoldWorkload(
 func (s *apiScaffolder) scaffoldAPI(
 	scaffold *machinery.Scaffold,
 	workload workloadv1.WorkloadAPIBuilder,
+	storageVersion bool,
 ) error {
 	// scaffold the base api types
 	if err := scaffold.Execute(
-		&api.Types{Builder: workload},
+		&api.Types{Builder: workload, StorageVersion: storageVersion},
 		&api.Group{},
 	); err != nil {
 		return fmt.Errorf("%w; %s", err, ErrScaffoldAPITypes)
@@ -217,6 +292,17 @@ func (s *apiScaffolder) scaffoldAPI(
 	// scaffold the resources
 	if err := scaffold.Execute(
 		&resources.Resources{Builder: workload},
+		&resources.Status{Builder: workload},
+		&resources.Ready{Builder: workload},
+		&resources.Delete{Builder: workload},
+		&resources.Apply{Builder: workload},
+		&api.ConfigGroup{},
+		&api.ConfigTypes{},
+		&intconfig.Config{},
+		&metrics.Metrics{},
+		&tracing.Tracing{},
+		&requeue.Requeue{},
+		&scope.Scope{},
 	); err != nil {
 		return fmt.Errorf("%w; %s", err, ErrScaffoldAPIResources)
 	}
@@ -0,0 +1,209 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/api"
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+const (
+	storageVersionMarker = "// +kubebuilder:storageversion"
+	webhookVersion       = "v1"
+)
+
+var (
+	ErrScaffoldConversion = errors.New("error scaffolding api version conversion")
+	ErrReadAPITypes       = errors.New("unable to read api types")
+)
+
+// previousVersions returns the resources of the project which share the group and kind
+// of a resource but are served at a different version.
+func previousVersions(cfg config.Config, res *resource.Resource) ([]resource.Resource, error) {
+	resources, err := cfg.GetResources()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve project resources, %w", err)
+	}
+
+	previous := []resource.Resource{}
+
+	for _, r := range resources {
+		if r.Group == res.Group && r.Kind == res.Kind && r.Version != res.Version {
+			previous = append(previous, r)
+		}
+	}
+
+	return previous, nil
+}
+
+// scaffoldConversion scaffolds the version being created as the hub of a kind and converts
+// each previous version of the kind, as a spoke, to and from it.
+func (s *apiScaffolder) scaffoldConversion(
+	scaffold *machinery.Scaffold,
+	res *resource.Resource,
+	spokes []resource.Resource,
+) error {
+	hub, err := s.parseAPISpecFields(res.Group, res.Version, res.Kind)
+	if err != nil {
+		return err
+	}
+
+	if err := scaffold.Execute(&api.ConversionHub{}); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldConversion)
+	}
+
+	for _, spoke := range spokes {
+		fields, err := s.parseAPISpecFields(spoke.Group, spoke.Version, spoke.Kind)
+		if err != nil {
+			return err
+		}
+
+		// only the hub version may be stored in the cluster
+		if err := s.removeStorageVersion(spoke.Group, spoke.Version, spoke.Kind); err != nil {
+			return err
+		}
+
+		// the existing conversion, along with any changes the user has made to it, is kept
+		// unless it converts to a hub which is no longer the hub
+		force, err := s.hubChanged(res, spoke)
+		if err != nil {
+			return err
+		}
+
+		if err := scaffold.Execute(
+			&api.ConversionSpoke{
+				Conversion: &workloadv1.APIConversion{
+					Kind:         res.Kind,
+					SpokeVersion: spoke.Version,
+					HubVersion:   res.Version,
+					Spoke:        fields,
+					Hub:          hub,
+				},
+				Force: force,
+			},
+		); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrScaffoldConversion)
+		}
+	}
+
+	// record the conversion webhook for the resource in the project
+	if res.Webhooks == nil {
+		res.Webhooks = &resource.Webhooks{}
+	}
+
+	res.Webhooks.WebhookVersion = webhookVersion
+	res.Webhooks.Conversion = true
+
+	if err := s.config.UpdateResource(*res); err != nil {
+		return fmt.Errorf("unable to record conversion webhook for %s, %w", res.Kind, err)
+	}
+
+	return nil
+}
+
+// parseAPISpecFields rebuilds the api field tree for a version of a kind from its api
+// types file.
+func (s *apiScaffolder) parseAPISpecFields(group, version, kind string) (*workloadv1.APIFields, error) {
+	path := typesPath(group, version, kind)
+
+	src, err := afero.ReadFile(s.fs.FS, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s, %s", ErrReadAPITypes, path, err)
+	}
+
+	fields, err := workloadv1.ParseAPISpecFields(src, kind)
+	if err != nil {
+		return nil, fmt.Errorf("%w for %s, %s", ErrReadAPITypes, path, err)
+	}
+
+	return fields, nil
+}
+
+// removeStorageVersion removes the storage version marker from the api types of a
+// version which was previously the hub.
+func (s *apiScaffolder) removeStorageVersion(group, version, kind string) error {
+	path := typesPath(group, version, kind)
+
+	info, err := s.fs.FS.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%w for %s, %s", ErrReadAPITypes, path, err)
+	}
+
+	src, err := afero.ReadFile(s.fs.FS, path)
+	if err != nil {
+		return fmt.Errorf("%w for %s, %s", ErrReadAPITypes, path, err)
+	}
+
+	if !bytes.Contains(src, []byte(storageVersionMarker+"\n")) {
+		return nil
+	}
+
+	src = bytes.Replace(src, []byte(storageVersionMarker+"\n"), nil, 1)
+
+	if err := afero.WriteFile(s.fs.FS, path, src, info.Mode()); err != nil {
+		return fmt.Errorf("unable to write %s, %w", path, err)
+	}
+
+	return nil
+}
+
+// hubChanged determines if the existing conversion of a spoke converts to a version
+// other than the hub.
+func (s *apiScaffolder) hubChanged(hub *resource.Resource, spoke resource.Resource) (bool, error) {
+	path := filepath.Join(
+		"apis",
+		spoke.Group,
+		spoke.Version,
+		fmt.Sprintf("%s_conversion.go", strings.ToLower(spoke.Kind)),
+	)
+
+	src, err := afero.ReadFile(s.fs.FS, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to read %s, %w", path, err)
+	}
+
+	hubImport := fmt.Sprintf("/apis/%s/%s\"", hub.Group, hub.Version)
+
+	return !strings.Contains(string(src), hubImport), nil
+}
+
+// hasStorageVersion determines if the api types of a version are marked as the version
+// stored in the cluster.
+func hasStorageVersion(fs afero.Fs, res *resource.Resource) (bool, error) {
+	src, err := afero.ReadFile(fs, typesPath(res.Group, res.Version, res.Kind))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("%w, %s", ErrReadAPITypes, err)
+	}
+
+	return bytes.Contains(src, []byte(storageVersionMarker+"\n")), nil
+}
+
+func typesPath(group, version, kind string) string {
+	return filepath.Join(
+		"apis",
+		group,
+		version,
+		fmt.Sprintf("%s_types.go", strings.ToLower(kind)),
+	)
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+const diffContextLines = 3
+
+// file states reported by a preview.
+const (
+	previewCreated   = "created"
+	previewModified  = "modified"
+	previewUnchanged = "unchanged"
+)
+
+// Preview captures the files written by a scaffolder in memory so that they may be
+// compared against the current project without modifying it.
+type Preview struct {
+	base  afero.Fs
+	layer afero.Fs
+}
+
+// NewPreview returns a new preview of changes to the provided filesystem.
+func NewPreview(fs machinery.Filesystem) *Preview {
+	return &Preview{
+		base:  fs.FS,
+		layer: afero.NewMemMapFs(),
+	}
+}
+
+// FS returns the filesystem which scaffolders write to during a preview.  Existing
+// files are read from the project while all writes are kept in memory.
+func (p *Preview) FS() machinery.Filesystem {
+	return machinery.Filesystem{
+		FS: afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(p.base), p.layer),
+	}
+}
+
+// Write writes the list of files that would be written, followed by a unified diff
+// of each file that would be created or modified.
+func (p *Preview) Write(w io.Writer) error {
+	paths, err := p.files()
+	if err != nil {
+		return err
+	}
+
+	diffs := &bytes.Buffer{}
+
+	fmt.Fprintln(w, "Files that would be written:")
+
+	for _, path := range paths {
+		state, diff, err := p.diff(path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "  %-10s %s\n", state, path)
+
+		diffs.WriteString(diff)
+	}
+
+	if diffs.Len() > 0 {
+		fmt.Fprintf(w, "\n%s", diffs.String())
+	}
+
+	return nil
+}
+
+// files returns the sorted paths of all files written to the in-memory layer.
+func (p *Preview) files() ([]string, error) {
+	var paths []string
+
+	if err := afero.Walk(p.layer, ".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			paths = append(paths, path)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("unable to list previewed files, %w", err)
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// diff returns the state of a previewed file along with its unified diff against the
+// project.
+func (p *Preview) diff(path string) (string, string, error) {
+	generated, err := afero.ReadFile(p.layer, path)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to read previewed file %s, %w", path, err)
+	}
+
+	state := previewModified
+
+	current, err := afero.ReadFile(p.base, path)
+
+	switch {
+	case os.IsNotExist(err):
+		state = previewCreated
+	case err != nil:
+		return "", "", fmt.Errorf("unable to read project file %s, %w", path, err)
+	case bytes.Equal(current, generated):
+		return previewUnchanged, "", nil
+	}
+
+	fromFile, fromLines := filepath.Join("a", path), difflib.SplitLines(string(current))
+	if state == previewCreated {
+		fromFile, fromLines = os.DevNull, nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        fromLines,
+		B:        difflib.SplitLines(string(generated)),
+		FromFile: fromFile,
+		ToFile:   filepath.Join("b", path),
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("unable to generate diff for %s, %w", path, err)
+	}
+
+	return state, diff, nil
+}
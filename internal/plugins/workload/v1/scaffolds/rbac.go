@@ -0,0 +1,89 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/rbac"
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var rbacKustomizationPath = filepath.Join("config", "rbac", "kustomization.yaml")
+
+const rbacRoleBindingLine = "- role_binding.yaml"
+
+// namespacedRBACLines are the commented lines of the rbac kustomization which select the
+// namespaced roles of the manager in place of its cluster roles.
+var namespacedRBACLines = []string{
+	"# [NAMESPACED] To grant the manager roles in only the namespaces which it watches, e.g. where",
+	"# cluster-wide RBAC is forbidden, comment role.yaml and role_binding.yaml and uncomment the",
+	"# following line.  Deploy the roles to the other watched namespaces with",
+	"# 'make deploy-namespaced-rbac WATCH_NAMESPACES=tenant-a,tenant-b'.  The roles of the auth",
+	"# proxy are cluster roles as well; comment them and the auth proxy patch of config/default",
+	"# to disable the auth proxy.",
+	"#- namespaced",
+}
+
+// scaffoldNamespacedRBAC scaffolds the role of the controller of a namespace-scoped workload,
+// which is granted to the manager in place of its cluster role when the manager only watches
+// a set of namespaces, and adds its selection to the rbac kustomization.
+func scaffoldNamespacedRBAC(fs afero.Fs, scaffold *machinery.Scaffold, workload workloadv1.WorkloadAPIBuilder) error {
+	if err := scaffold.Execute(
+		&rbac.Role{Builder: workload},
+		&rbac.Kustomization{},
+		&rbac.WatchNamespace{},
+	); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldRBAC)
+	}
+
+	return insertNamespacedRBAC(fs, rbacKustomizationPath)
+}
+
+// insertNamespacedRBAC inserts the commented selection of the namespaced roles of the manager
+// following the role binding of the rbac kustomization, unless the selection already exists.
+// A file which does not exist is ignored, as the file is scaffolded by another plugin.
+func insertNamespacedRBAC(fs afero.Fs, path string) error {
+	info, err := fs.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to read %s, %w", path, err)
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s, %w", path, err)
+	}
+
+	existing := strings.Split(string(content), "\n")
+	if containsLine(existing, "#- namespaced") || containsLine(existing, "- namespaced") {
+		return nil
+	}
+
+	lines := make([]string, 0, len(existing)+len(namespacedRBACLines))
+
+	for _, line := range existing {
+		lines = append(lines, line)
+
+		if line == rbacRoleBindingLine {
+			lines = append(lines, namespacedRBACLines...)
+		}
+	}
+
+	if err := afero.WriteFile(fs, path, []byte(strings.Join(lines, "\n")), info.Mode()); err != nil {
+		return fmt.Errorf("unable to write %s, %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_insertNamespacedRBAC(t *testing.T) {
+	t.Parallel()
+
+	selection := strings.Join(namespacedRBACLines, "\n")
+
+	tests := []struct {
+		name     string
+		existing string
+		expected string
+	}{
+		{
+			name:     "selection follows the role binding",
+			existing: "resources:\n- service_account.yaml\n- role.yaml\n- role_binding.yaml\n- leader_election_role.yaml\n",
+			expected: "resources:\n- service_account.yaml\n- role.yaml\n- role_binding.yaml\n" +
+				selection + "\n- leader_election_role.yaml\n",
+		},
+		{
+			name:     "commented selection is kept",
+			existing: "resources:\n- role.yaml\n- role_binding.yaml\n#- namespaced\n",
+			expected: "resources:\n- role.yaml\n- role_binding.yaml\n#- namespaced\n",
+		},
+		{
+			name:     "uncommented selection is kept",
+			existing: "resources:\n#- role.yaml\n#- role_binding.yaml\n- namespaced\n",
+			expected: "resources:\n#- role.yaml\n#- role_binding.yaml\n- namespaced\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, rbacKustomizationPath, []byte(tt.existing), 0o600))
+
+			require.NoError(t, insertNamespacedRBAC(fs, rbacKustomizationPath))
+
+			content, err := afero.ReadFile(fs, rbacKustomizationPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
+
+func Test_insertNamespacedRBAC_missingFile(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, insertNamespacedRBAC(afero.NewMemMapFs(), rbacKustomizationPath))
+}
@@ -0,0 +1,339 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/afero/mem"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var (
+	ErrUserRegionUnterminated = errors.New("user region is missing its end marker")
+	ErrUserRegionMismatched   = errors.New("user region end marker does not match its begin marker")
+	ErrUserRegionDuplicate    = errors.New("user region is defined more than once")
+	ErrUserRegionOrphaned     = errors.New("user region no longer exists in the scaffolded file")
+)
+
+// user regions are delimited by the following markers within scaffolded files.  The
+// content between the markers is owned by the user and preserved when a file is
+// scaffolded again.
+const (
+	userRegionBeginMarker = "//+operator-builder:user:begin:"
+	userRegionEndMarker   = "//+operator-builder:user:end:"
+)
+
+// maxUserRegionLineSize is the maximum length of a line within a file with user regions.
+const maxUserRegionLineSize = 1024 * 1024
+
+// UserRegions contains the content of files with user regions, keyed by file path, as
+// they existed prior to scaffolding.
+type UserRegions map[string][]byte
+
+// userRegionFs is a filesystem which preserves the content of user regions when a
+// file containing them is overwritten.
+type userRegionFs struct {
+	afero.Fs
+
+	snapshot UserRegions
+}
+
+// userRegionFile buffers writes to a file in memory so that the user regions of the
+// existing file may be merged into the new content before it is written.
+type userRegionFile struct {
+	afero.File
+
+	fs        afero.Fs
+	flag      int
+	perm      os.FileMode
+	existing  []byte
+	generated []byte
+	merged    []byte
+	err       error
+}
+
+// SnapshotUserRegions captures the files within a project which contain user regions.
+// The snapshot must be taken before any plugin scaffolds files, as other plugins in the
+// chain may overwrite the same files before this plugin scaffolds them.
+func SnapshotUserRegions(fs machinery.Filesystem) (UserRegions, error) {
+	snapshot := UserRegions{}
+
+	if err := afero.Walk(fs.FS, ".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != "." && (strings.HasPrefix(info.Name(), ".") || info.Name() == "vendor" || info.Name() == "bin") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		content, err := afero.ReadFile(fs.FS, path)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Contains(content, []byte(userRegionBeginMarker)) {
+			snapshot[filepath.Clean(path)] = content
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("unable to snapshot user regions, %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// newUserRegionFs returns a filesystem which preserves user regions of files written
+// to the underlying filesystem.  User regions are taken from the snapshot if the file
+// exists within it, otherwise from the file as it exists when it is overwritten.
+func newUserRegionFs(fs afero.Fs, snapshot UserRegions) afero.Fs {
+	return &userRegionFs{Fs: fs, snapshot: snapshot}
+}
+
+// Create implements afero.Fs.
+func (fs *userRegionFs) Create(name string) (afero.File, error) {
+	return fs.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666) //nolint:gomnd
+}
+
+// OpenFile implements afero.Fs.  Files which are truncated while user regions exist
+// within them are written only once closed, after the user regions have been merged.
+func (fs *userRegionFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&os.O_TRUNC == 0 {
+		return fs.Fs.OpenFile(name, flag, perm)
+	}
+
+	existing, found := fs.snapshot[filepath.Clean(name)]
+	if !found {
+		var err error
+
+		if existing, err = afero.ReadFile(fs.Fs, name); err != nil {
+			return fs.Fs.OpenFile(name, flag, perm)
+		}
+	}
+
+	if !bytes.Contains(existing, []byte(userRegionBeginMarker)) {
+		return fs.Fs.OpenFile(name, flag, perm)
+	}
+
+	return &userRegionFile{
+		File:     mem.NewFileHandle(mem.CreateFile(name)),
+		fs:       fs.Fs,
+		flag:     flag,
+		perm:     perm,
+		existing: existing,
+	}, nil
+}
+
+// Write implements afero.File.  The user regions of the existing file are merged into
+// the content as it is written, rather than when the file is closed, so that an error
+// merging them is returned by the scaffolding machinery, which writes the content of a
+// file with a single write.
+func (f *userRegionFile) Write(p []byte) (int, error) {
+	f.generated = append(f.generated, p...)
+
+	if err := f.merge(); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// merge merges the user regions of the existing file into the content written so far.
+func (f *userRegionFile) merge() error {
+	merged, err := mergeUserRegions(f.generated, f.existing)
+	if err != nil {
+		f.err = fmt.Errorf("unable to preserve user regions of %s, %w", f.Name(), err)
+
+		return f.err
+	}
+
+	f.merged, f.err = merged, nil
+
+	return nil
+}
+
+// Close implements afero.File.  The merged content is written to the underlying
+// filesystem.  If the user regions could not be merged, the existing content is
+// written instead, as another plugin may have already overwritten the file, and the
+// error is returned.
+func (f *userRegionFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		return fmt.Errorf("unable to close scaffolded file %s, %w", f.Name(), err)
+	}
+
+	if f.merged == nil && f.err == nil {
+		_ = f.merge()
+	}
+
+	content := f.merged
+	if f.err != nil {
+		content = f.existing
+	}
+
+	file, err := f.fs.OpenFile(f.Name(), f.flag, f.perm)
+	if err != nil {
+		return fmt.Errorf("unable to open %s, %w", f.Name(), err)
+	}
+
+	if _, err := file.Write(content); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("unable to write %s, %w", f.Name(), err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("unable to close %s, %w", f.Name(), err)
+	}
+
+	return f.err
+}
+
+// mergeUserRegions replaces the content of each user region within the generated
+// content with the content of the same region from the existing content.  An error is
+// returned rather than discarding a non-empty user region which no longer exists.
+func mergeUserRegions(generated, existing []byte) ([]byte, error) {
+	regions, err := parseUserRegions(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(regions) == 0 {
+		return generated, nil
+	}
+
+	var merged bytes.Buffer
+
+	var current string
+
+	used := map[string]bool{}
+
+	scanner := newLineScanner(generated)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if name, ok := userRegionName(line, userRegionEndMarker); ok && name == current {
+			current = ""
+		}
+
+		// skip the generated content of a region which is being replaced
+		if _, replaced := regions[current]; current != "" && replaced {
+			continue
+		}
+
+		merged.WriteString(line + "\n")
+
+		if name, ok := userRegionName(line, userRegionBeginMarker); ok {
+			current = name
+
+			if content, exists := regions[name]; exists {
+				merged.WriteString(content)
+
+				used[name] = true
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read generated content, %w", err)
+	}
+
+	for name, content := range regions {
+		if !used[name] && strings.TrimSpace(content) != "" {
+			return nil, fmt.Errorf("%w: %s", ErrUserRegionOrphaned, name)
+		}
+	}
+
+	return merged.Bytes(), nil
+}
+
+// parseUserRegions returns the content of each user region, keyed by region name.
+func parseUserRegions(content []byte) (map[string]string, error) {
+	regions := map[string]string{}
+
+	var current string
+
+	var body strings.Builder
+
+	scanner := newLineScanner(content)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if name, ok := userRegionName(line, userRegionBeginMarker); ok {
+			if current != "" {
+				return nil, fmt.Errorf("%w: %s", ErrUserRegionUnterminated, current)
+			}
+
+			if _, exists := regions[name]; exists {
+				return nil, fmt.Errorf("%w: %s", ErrUserRegionDuplicate, name)
+			}
+
+			current = name
+
+			body.Reset()
+
+			continue
+		}
+
+		if name, ok := userRegionName(line, userRegionEndMarker); ok {
+			if name != current {
+				return nil, fmt.Errorf("%w: expected %q, found %q", ErrUserRegionMismatched, current, name)
+			}
+
+			regions[current] = body.String()
+			current = ""
+
+			continue
+		}
+
+		if current != "" {
+			body.WriteString(line + "\n")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read existing content, %w", err)
+	}
+
+	if current != "" {
+		return nil, fmt.Errorf("%w: %s", ErrUserRegionUnterminated, current)
+	}
+
+	return regions, nil
+}
+
+// newLineScanner returns a scanner of the lines of content, which allows lines of up to
+// the max line size rather than the default token size of a scanner.
+func newLineScanner(content []byte) *bufio.Scanner {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxUserRegionLineSize)
+
+	return scanner
+}
+
+// userRegionName returns the name of the user region if the line contains the marker.
+func userRegionName(line, marker string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, marker) {
+		return "", false
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(trimmed, marker)), true
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mergeUserRegions(t *testing.T) {
+	t.Parallel()
+
+	longLine := "\t// " + strings.Repeat("x", 128*1024) + "\n"
+
+	tests := []struct {
+		name      string
+		generated string
+		existing  string
+		expected  string
+		wantErr   error
+	}{
+		{
+			name: "user region content replaces the generated content",
+			generated: `package main
+//+operator-builder:user:begin:imports
+//+operator-builder:user:end:imports
+func main() {}
+`,
+			existing: `package main
+//+operator-builder:user:begin:imports
+import "fmt"
+//+operator-builder:user:end:imports
+func old() {}
+`,
+			expected: `package main
+//+operator-builder:user:begin:imports
+import "fmt"
+//+operator-builder:user:end:imports
+func main() {}
+`,
+		},
+		{
+			name: "generated content of a region which did not exist is kept",
+			generated: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+//+operator-builder:user:begin:b
+	// generated
+//+operator-builder:user:end:b
+`,
+			existing: `//+operator-builder:user:begin:a
+	// user
+//+operator-builder:user:end:a
+`,
+			expected: `//+operator-builder:user:begin:a
+	// user
+//+operator-builder:user:end:a
+//+operator-builder:user:begin:b
+	// generated
+//+operator-builder:user:end:b
+`,
+		},
+		{
+			name: "indented markers are recognized",
+			generated: `func f() {
+	//+operator-builder:user:begin:body
+	//+operator-builder:user:end:body
+}
+`,
+			existing: `func f() {
+	//+operator-builder:user:begin:body
+	return
+	//+operator-builder:user:end:body
+}
+`,
+			expected: `func f() {
+	//+operator-builder:user:begin:body
+	return
+	//+operator-builder:user:end:body
+}
+`,
+		},
+		{
+			name: "long lines within a user region are preserved",
+			generated: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+`,
+			existing: "//+operator-builder:user:begin:a\n" + longLine + "//+operator-builder:user:end:a\n",
+			expected: "//+operator-builder:user:begin:a\n" + longLine + "//+operator-builder:user:end:a\n",
+		},
+		{
+			name: "renamed region with content results in an error",
+			generated: `//+operator-builder:user:begin:renamed
+//+operator-builder:user:end:renamed
+`,
+			existing: `//+operator-builder:user:begin:original
+	// user
+//+operator-builder:user:end:original
+`,
+			wantErr: ErrUserRegionOrphaned,
+		},
+		{
+			name: "renamed region without content is dropped",
+			generated: `//+operator-builder:user:begin:renamed
+//+operator-builder:user:end:renamed
+`,
+			existing: `//+operator-builder:user:begin:original
+
+//+operator-builder:user:end:original
+`,
+			expected: `//+operator-builder:user:begin:renamed
+//+operator-builder:user:end:renamed
+`,
+		},
+		{
+			name: "nested region results in an error",
+			generated: `//+operator-builder:user:begin:outer
+//+operator-builder:user:end:outer
+`,
+			existing: `//+operator-builder:user:begin:outer
+//+operator-builder:user:begin:inner
+//+operator-builder:user:end:inner
+//+operator-builder:user:end:outer
+`,
+			wantErr: ErrUserRegionUnterminated,
+		},
+		{
+			name: "region without an end marker results in an error",
+			generated: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+`,
+			existing: `//+operator-builder:user:begin:a
+	// user
+`,
+			wantErr: ErrUserRegionUnterminated,
+		},
+		{
+			name: "end marker without a begin marker results in an error",
+			generated: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+`,
+			existing: `	// user
+//+operator-builder:user:end:a
+`,
+			wantErr: ErrUserRegionMismatched,
+		},
+		{
+			name: "end marker of another region results in an error",
+			generated: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+`,
+			existing: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:b
+`,
+			wantErr: ErrUserRegionMismatched,
+		},
+		{
+			name: "duplicate region results in an error",
+			generated: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+`,
+			existing: `//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+//+operator-builder:user:begin:a
+//+operator-builder:user:end:a
+`,
+			wantErr: ErrUserRegionDuplicate,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, err := mergeUserRegions([]byte(tt.generated), []byte(tt.existing))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(merged))
+		})
+	}
+}
+
+func Test_userRegionFs_mergeError(t *testing.T) {
+	t.Parallel()
+
+	existing := `//+operator-builder:user:begin:original
+	// user
+//+operator-builder:user:end:original
+`
+
+	fs := newUserRegionFs(afero.NewMemMapFs(), UserRegions{"main.go": []byte(existing)})
+
+	file, err := fs.Create("main.go")
+	require.NoError(t, err)
+
+	// the error is returned from the write so that the scaffolding machinery surfaces it
+	_, err = file.Write([]byte("//+operator-builder:user:begin:renamed\n//+operator-builder:user:end:renamed\n"))
+	assert.ErrorIs(t, err, ErrUserRegionOrphaned)
+	assert.ErrorIs(t, file.Close(), ErrUserRegionOrphaned)
+
+	// the existing content is kept rather than discarding the user region
+	content, err := afero.ReadFile(fs, "main.go")
+	require.NoError(t, err)
+	assert.Equal(t, existing, string(content))
+}
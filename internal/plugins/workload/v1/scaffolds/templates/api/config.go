@@ -0,0 +1,159 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var (
+	_ machinery.Template = &ConfigGroup{}
+	_ machinery.Template = &ConfigTypes{}
+)
+
+// ConfigGroup scaffolds the file that defines the registration methods for the group and
+// version of the controller manager config.
+type ConfigGroup struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.DomainMixin
+}
+
+func (f *ConfigGroup) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		"config",
+		"v1alpha1",
+		"groupversion_info.go",
+	)
+
+	f.TemplateBody = configGroupTemplate
+
+	return nil
+}
+
+// ConfigTypes scaffolds the typed controller manager config of the project, which holds the
+// settings of the manager and of the controller of each workload.
+type ConfigTypes struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+func (f *ConfigTypes) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		"config",
+		"v1alpha1",
+		"controllermanagerconfig_types.go",
+	)
+
+	f.TemplateBody = configTypesTemplate
+
+	return nil
+}
+
+const configGroupTemplate = `{{ .Boilerplate }}
+
+// Package v1alpha1 contains the controller manager config of the project, which is read from
+// the config file of the manager.  It is not served by the API server, so no CRD is generated
+// for it.
+//+kubebuilder:object:generate=true
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "config.{{ .Domain }}", Version: "v1alpha1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+`
+
+const configTypesTemplate = `{{ .Boilerplate }}
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	componentconfig "k8s.io/component-base/config/v1alpha1"
+	cfg "sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
+)
+
+//+kubebuilder:object:root=true
+
+// ControllerManagerConfig is the config of the controller manager, which sets the metrics and
+// health probe addresses and the leader election of the manager, the namespaces which it
+// watches and the settings of the controller of each workload.
+type ControllerManagerConfig struct {
+	metav1.TypeMeta ` + "`" + `json:",inline"` + "`" + `
+
+	// ControllerManagerConfigurationSpec returns the configurations for controllers
+	cfg.ControllerManagerConfigurationSpec ` + "`" + `json:",inline"` + "`" + `
+
+	// Namespaces are the namespaces which are watched by the manager.  The manager watches all
+	// namespaces when there are none.
+	// +optional
+	Namespaces []string ` + "`" + `json:"namespaces,omitempty"` + "`" + `
+
+	// Workloads are the settings of the controller of each workload by its kind.
+	// +optional
+	Workloads map[string]WorkloadConfig ` + "`" + `json:"workloads,omitempty"` + "`" + `
+}
+
+// WorkloadConfig is the config of the controller of a workload.
+type WorkloadConfig struct {
+	// MaxConcurrentReconciles is the number of custom resources of the workload which are
+	// reconciled at the same time.
+	// +optional
+	MaxConcurrentReconciles int ` + "`" + `json:"maxConcurrentReconciles,omitempty"` + "`" + `
+
+	// Requeue are the requeue policies of the phases of the controller by the name of the phase,
+	// which override the requeue policies that were generated from the workload config.
+	// +optional
+	Requeue map[string]RequeuePolicy ` + "`" + `json:"requeue,omitempty"` + "`" + `
+}
+
+// RequeuePolicy sets how a workload is requeued by a phase which is not ready to proceed.  Only
+// the values which are set override the generated requeue policy of the phase.
+type RequeuePolicy struct {
+	// Interval is the time after which the workload is requeued.
+	// +optional
+	Interval *metav1.Duration ` + "`" + `json:"interval,omitempty"` + "`" + `
+
+	// MaxInterval caps the interval, which is doubled on each consecutive requeue by the phase.
+	// +optional
+	MaxInterval *metav1.Duration ` + "`" + `json:"maxInterval,omitempty"` + "`" + `
+
+	// MaxRetries is the number of consecutive requeues by the phase after which the workload is
+	// no longer requeued.
+	// +optional
+	MaxRetries *int ` + "`" + `json:"maxRetries,omitempty"` + "`" + `
+}
+
+// Complete returns the configuration of the manager.
+func (c *ControllerManagerConfig) Complete() (cfg.ControllerManagerConfigurationSpec, error) {
+	spec := c.ControllerManagerConfigurationSpec
+
+	// the leader election is read by the manager whether or not it is set in the config file
+	if spec.LeaderElection == nil {
+		spec.LeaderElection = &componentconfig.LeaderElectionConfiguration{}
+	}
+
+	return spec, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&ControllerManagerConfig{})
+}
+`
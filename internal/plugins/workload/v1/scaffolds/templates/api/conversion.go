@@ -0,0 +1,139 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var (
+	_ machinery.Template = &ConversionHub{}
+	_ machinery.Template = &ConversionSpoke{}
+)
+
+// ConversionHub scaffolds the file that marks a version of a kind as the hub which all
+// other versions of the kind are converted to and from.
+type ConversionHub struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+}
+
+// SetTemplateDefaults implements file.Template.
+func (f *ConversionHub) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		f.Resource.Group,
+		f.Resource.Version,
+		fmt.Sprintf("%s_conversion.go", strings.ToLower(f.Resource.Kind)),
+	)
+
+	f.TemplateBody = conversionHubTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+// ConversionSpoke scaffolds the file that converts a previous version of a kind to and
+// from the hub version.
+type ConversionSpoke struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Conversion *workloadv1.APIConversion
+
+	// Force overwrites an existing file.  This is needed when the hub version of the
+	// kind has changed and the existing conversion no longer compiles.
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template.
+func (f *ConversionSpoke) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		f.Resource.Group,
+		f.Conversion.SpokeVersion,
+		fmt.Sprintf("%s_conversion.go", strings.ToLower(f.Resource.Kind)),
+	)
+
+	f.TemplateBody = conversionSpokeTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const conversionHubTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+// Code generated by operator-builder. DO NOT EDIT.
+
+// Hub marks this version as the hub which all other versions of {{ .Resource.Kind }} are
+// converted to and from.
+func (*{{ .Resource.Kind }}) Hub() {}
+`
+
+const conversionSpokeTemplate = `{{ .Boilerplate }}
+
+package {{ .Conversion.SpokeVersion }}
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	{{ .Conversion.HubVersion }}{{ .Resource.Group }} "{{ .Repo }}/apis/{{ .Resource.Group }}/{{ .Conversion.HubVersion }}"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: fields which are identically named in both versions are copied automatically.  Each
+// field which was added, removed or changed between the versions is left as a TODO.  This
+// file is not overwritten unless the hub version of {{ .Resource.Kind }} changes.
+
+// ConvertTo converts this {{ .Resource.Kind }} to the hub version ({{ .Conversion.HubVersion }}).
+func (src *{{ .Resource.Kind }}) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*{{ .Conversion.HubVersion }}{{ .Resource.Group }}.{{ .Resource.Kind }})
+	if !ok {
+		return fmt.Errorf("%w; expected %T but received %T", ErrUnableToConvert{{ .Resource.Kind }}, dst, dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	{{ .Conversion.GenerateConvertTo }}
+	// the status is identical between versions unless fields were added to it by hand
+	dst.Status = {{ .Conversion.HubVersion }}{{ .Resource.Group }}.{{ .Resource.Kind }}Status(src.Status)
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version ({{ .Conversion.HubVersion }}) to this version.
+func (dst *{{ .Resource.Kind }}) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*{{ .Conversion.HubVersion }}{{ .Resource.Group }}.{{ .Resource.Kind }})
+	if !ok {
+		return fmt.Errorf("%w; expected %T but received %T", ErrUnableToConvert{{ .Resource.Kind }}, src, srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	{{ .Conversion.GenerateConvertFrom }}
+	// the status is identical between versions unless fields were added to it by hand
+	dst.Status = {{ .Resource.Kind }}Status(src.Status)
+
+	return nil
+}
+`
@@ -0,0 +1,607 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ machinery.Template = &Apply{}
+
+// Apply scaffolds the function which applies the child resources of a workload with
+// server-side apply and reports the fields which were changed by other field managers.
+type Apply struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
+}
+
+func (f *Apply) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		f.Resource.Group,
+		f.Resource.Version,
+		f.Builder.GetPackageName(),
+		"apply.go",
+	)
+
+	f.TemplateBody = applyTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const applyTemplate = `{{ .Boilerplate }}
+
+package {{ .Builder.GetPackageName }}
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/reconcile"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/nukleros/operator-builder-tools/pkg/resources"
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+	{{- if .Builder.IsComponent }}
+	{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }} "{{ .Repo }}/apis/{{ .Builder.GetCollection.Spec.API.Group }}/{{ .Builder.GetCollection.Spec.API.Version }}"
+	{{- end }}
+	"{{ .Repo }}/internal/metrics"
+	"{{ .Repo }}/internal/scope"
+	"{{ .Repo }}/internal/tracing"
+)
+
+// DriftConditionType is the type of the condition of the {{ .Resource.Kind }} which reports the
+// fields of its child resources that were changed by other field managers.
+const DriftConditionType = "Drifted"
+
+// maxDriftMessageLength is the maximum length of the message of a condition.
+const maxDriftMessageLength = 32768
+
+// driftRule sets how a child resource is applied when its fields are changed by other field
+// managers.  Changed fields are reverted unless revert is false, in which case they are left
+// to the other field managers, and fields at the ignore paths follow their values in the
+// cluster once the child resource exists.
+type driftRule struct {
+	create func(
+		*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+		{{- if .Builder.IsComponent }}
+		*{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+		{{- end }}
+	) ([]client.Object, error)
+	revert bool
+	ignore []string
+}
+{{- if .Builder.GetDriftRules }}
+
+// driftRules are the rules of the child resources with drift markers.  Drift of the other
+// child resources is reverted.
+var driftRules = []driftRule{
+	{{- range .Builder.GetDriftRules }}
+	{
+		create: {{ .CreateFunc }},
+		revert: {{ .Revert }},
+		{{- if .Ignore }}
+		ignore: []string{
+			{{- range .Ignore }}
+			{{ printf "%q" . }},
+			{{- end }}
+		},
+		{{- end }}
+	},
+	{{- end }}
+}
+{{- end }}
+
+// driftField is a field of a child resource which was changed by another field manager.
+type driftField struct {
+	path    string
+	manager string
+}
+
+// ApplyResources applies the child resources of the {{ .Resource.Kind }}, as returned by the
+// reconciler, with server-side apply and returns whether each was applied.  The fields of the
+// child resources which were changed by other field managers are reported with an event and
+// the Drifted condition of the {{ .Resource.Kind }}, which is persisted with the phase conditions.
+func ApplyResources(
+	r workload.Reconciler,
+	req *workload.Request,
+	parent *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{- if .Builder.IsComponent }}
+	collection *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{- end }}
+) (bool, error) {
+	resourceObjs, err := r.GetResources(req)
+	if err != nil {
+		return false, fmt.Errorf("unable to retrieve resources, %w", err)
+	}
+
+	proceed := true
+	drift := []string{}
+
+	var applyErr error
+
+	for _, resourceObj := range resourceObjs {
+		rule, err := driftRuleFor(parent{{ if .Builder.IsComponent }}, collection{{ end }}, resourceObj)
+		if err != nil {
+			return false, err
+		}
+
+		end := tracing.Start(req, "Apply", tracing.ChildAttributes(resourceObj)...)
+
+		fields, applied, err := applyResource(r, req, resourceObj, rule)
+
+		end(err)
+
+		metrics.RecordApply(parent, resourceObj, applied, err)
+
+		for _, field := range fields {
+			drift = append(drift, driftMessage(resourceObj, field, rule.revert))
+		}
+
+		condition, applied, err := phases.HandleResourcePhaseExit(applied, err)
+		if err != nil {
+			req.Log.Error(err, "unable to apply resource")
+
+			applyErr = err
+		}
+
+		childResource := status.ToCommonResource(resourceObj)
+		childResource.ChildResourceCondition = condition
+		req.Workload.SetChildResourceCondition(childResource)
+
+		proceed = proceed && applied
+	}
+
+	if setDriftCondition(parent, drift) && len(drift) > 0 {
+		r.GetEventRecorder().Event(parent, corev1.EventTypeWarning, "DriftDetected", strings.Join(drift, "; "))
+	}
+
+	return proceed, applyErr
+}
+
+// driftRuleFor returns the drift rule of a child resource, which reverts each changed field
+// unless the child resource has drift markers.
+func driftRuleFor(
+	parent *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{- if .Builder.IsComponent }}
+	collection *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{- end }}
+	resourceObj client.Object,
+) (driftRule, error) {
+	{{- if .Builder.GetDriftRules }}
+	for _, rule := range driftRules {
+		candidates, err := rule.create(parent{{ if .Builder.IsComponent }}, collection{{ end }})
+		if err != nil {
+			return driftRule{}, fmt.Errorf("unable to create resources for drift rule, %w", err)
+		}
+
+		for _, candidate := range candidates {
+			if resources.EqualGVK(candidate, resourceObj) && resources.EqualNamespaceName(candidate, resourceObj) {
+				return rule, nil
+			}
+		}
+	}
+	{{- end }}
+
+	return driftRule{revert: true}, nil
+}
+
+// applyResource applies a child resource with server-side apply once its namespace is ready,
+// and returns the fields which were changed by other field managers and whether it was applied.
+func applyResource(
+	r workload.Reconciler,
+	req *workload.Request,
+	resourceObj client.Object,
+	rule driftRule,
+) ([]driftField, bool, error) {
+	// a manager which watches a set of namespaces may neither read the resources outside of the
+	// set, nor the namespaces themselves, which are cluster-scoped
+	if resourceObj.GetNamespace() != "" && !scope.Watches(resourceObj.GetNamespace()) {
+		return nil, false, fmt.Errorf("unable to apply %s, namespace %s is not watched by the manager",
+			resourceObj.GetName(), resourceObj.GetNamespace())
+	}
+
+	if resourceObj.GetNamespace() != "" && scope.IsClusterWide() {
+		ready, err := resources.NamespaceForResourceIsReady(r, req, resourceObj)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to determine if %s namespace is ready, %w", resourceObj.GetNamespace(), err)
+		}
+
+		if !ready {
+			return nil, false, nil
+		}
+	}
+
+	if err := setOwner(req.Workload, resourceObj, r.Scheme()); err != nil {
+		return nil, false, fmt.Errorf("unable to set owner reference on %s, %w", resourceObj.GetName(), err)
+	}
+
+	desired, err := resources.ToUnstructured(resourceObj)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to convert resource %s, %w", resourceObj.GetName(), err)
+	}
+
+	desired.SetManagedFields(nil)
+	desired.SetResourceVersion("")
+	unstructured.RemoveNestedField(desired.Object, "status")
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(desired.GroupVersionKind())
+
+	exists := true
+
+	if err := r.Get(req.Context, client.ObjectKeyFromObject(desired), current); err != nil {
+		if !apierrs.IsNotFound(err) {
+			return nil, false, fmt.Errorf("unable to get resource %s, %w", resourceObj.GetName(), err)
+		}
+
+		exists = false
+	}
+
+	// the ignored fields are applied with their values in the cluster, so that they are shared
+	// with the other field managers instead of conflicting with them
+	if exists {
+		for _, path := range rule.ignore {
+			if value, found := fieldValue(current.Object, path); found {
+				updateField(desired.Object, path, func(interface{}) interface{} { return value })
+			}
+		}
+	}
+
+	fields, err := apply(req.Context, r, r.GetFieldManager(), desired, rule.revert)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to apply resource %s, %w", resourceObj.GetName(), err)
+	}
+
+	if !exists {
+		if err := reconcile.Watch(r, req, resourceObj); err != nil {
+			return fields, false, err
+		}
+	}
+
+	return fields, true, nil
+}
+
+// setOwner sets the controller reference of a child resource to the parent.  A child resource
+// which cannot have an owner reference to a namespaced parent, as it is cluster-scoped or in
+// another namespace, is labeled with the parent instead, so that it is still watched.
+func setOwner(parent, resourceObj client.Object, scheme *runtime.Scheme) error {
+	if parent.GetNamespace() == "" || parent.GetNamespace() == resourceObj.GetNamespace() {
+		return ctrl.SetControllerReference(parent, resourceObj, scheme)
+	}
+
+	labels := resourceObj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}OwnerNameLabel] = parent.GetName()
+	labels[{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}OwnerNamespaceLabel] = parent.GetNamespace()
+
+	resourceObj.SetLabels(labels)
+
+	return nil
+}
+
+// apply applies an object with server-side apply and returns the fields which conflict with
+// other field managers.  The conflicting fields are taken over from the other field managers
+// when revert is true and are otherwise removed from the object, leaving them to the other
+// field managers.
+func apply(
+	ctx context.Context,
+	c client.Client,
+	fieldManager string,
+	desired *unstructured.Unstructured,
+	revert bool,
+) ([]driftField, error) {
+	err := c.Patch(ctx, desired.DeepCopy(), client.Apply, client.FieldOwner(fieldManager))
+	if err == nil {
+		return nil, nil
+	}
+
+	conflicts, ok := fieldConflicts(err)
+	if !ok {
+		return nil, err
+	}
+
+	fields := []driftField{}
+
+	for _, conflict := range conflicts {
+		// conflicts with the field manager itself are left over from updates of earlier versions
+		// of the controller and are not drift
+		if conflict.manager == fieldManager {
+			continue
+		}
+
+		fields = append(fields, conflict)
+
+		if !revert {
+			updateField(desired.Object, conflict.path, func(interface{}) interface{} { return nil })
+		}
+	}
+
+	if err := c.Patch(ctx, desired, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership); err != nil {
+		return fields, err
+	}
+
+	return fields, nil
+}
+
+// fieldConflicts returns the fields of a server-side apply conflict error and whether the
+// error is such a conflict.
+func fieldConflicts(err error) ([]driftField, bool) {
+	var statusErr apierrs.APIStatus
+
+	if !apierrs.IsConflict(err) || !errors.As(err, &statusErr) || statusErr.Status().Details == nil {
+		return nil, false
+	}
+
+	conflicts := []driftField{}
+
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		// the message of a cause is in the form: conflict with "manager" using apps/v1
+		manager := cause.Message
+		if fields := strings.Split(cause.Message, "\""); len(fields) > 2 {
+			manager = fields[1]
+		}
+
+		conflicts = append(conflicts, driftField{path: cause.Field, manager: manager})
+	}
+
+	return conflicts, len(conflicts) > 0
+}
+
+// driftMessage returns the message which reports a changed field of a child resource.
+func driftMessage(resourceObj client.Object, field driftField, reverted bool) string {
+	action := "left to " + field.manager
+	if reverted {
+		action = "reverted"
+	}
+
+	return fmt.Sprintf("%s %s: %s changed by %s, %s",
+		resourceObj.GetObjectKind().GroupVersionKind().Kind,
+		resourceObj.GetName(),
+		field.path,
+		field.manager,
+		action,
+	)
+}
+
+// setDriftCondition sets the Drifted condition of the {{ .Resource.Kind }} and returns whether
+// its message changed, so that drift which persists is only reported once.
+func setDriftCondition(parent *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}, drift []string) bool {
+	condition := metav1.Condition{
+		Type:               DriftConditionType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: parent.Generation,
+		Reason:             "NoDrift",
+		Message:            "no fields of the child resources were changed by other field managers",
+	}
+
+	if len(drift) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DriftDetected"
+		condition.Message = strings.Join(drift, "; ")
+
+		if len(condition.Message) > maxDriftMessageLength {
+			condition.Message = condition.Message[:maxDriftMessageLength-3] + "..."
+		}
+	}
+
+	existing := meta.FindStatusCondition(parent.Status.Conditions, DriftConditionType)
+	changed := existing == nil || existing.Message != condition.Message
+
+	meta.SetStatusCondition(&parent.Status.Conditions, condition)
+
+	return changed
+}
+
+// fieldValue returns the value at a field path of an object and whether it was found.
+func fieldValue(object map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{}
+
+	found := updateField(object, path, func(current interface{}) interface{} {
+		value = current
+
+		return current
+	})
+
+	return value, found
+}
+
+// updateField replaces the value at a field path of an object with the result of update, or
+// removes it when the result is nil, and returns whether the path was found.  A field path,
+// as reported by server-side apply, is a sequence of field names, each prefixed with a dot,
+// and of list selectors such as [name="nginx"], [="value"] or [0].
+func updateField(object map[string]interface{}, path string, update func(interface{}) interface{}) bool {
+	_, found := updateValue(object, path, update)
+
+	return found
+}
+
+func updateValue(value interface{}, path string, update func(interface{}) interface{}) (interface{}, bool) {
+	if path == "" {
+		return update(value), true
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if path[0] != '.' {
+			return value, false
+		}
+
+		// field names, such as those of labels, may contain dots, so the longest name wins
+		var name string
+
+		for key := range typed {
+			rest := strings.TrimPrefix(path[1:], key)
+			if len(key) > len(name) && len(rest) < len(path)-1 &&
+				(rest == "" || rest[0] == '.' || rest[0] == '[') {
+				name = key
+			}
+		}
+
+		if name == "" {
+			return value, false
+		}
+
+		updated, found := updateValue(typed[name], path[1+len(name):], update)
+		if !found {
+			return value, false
+		}
+
+		if updated == nil {
+			delete(typed, name)
+		} else {
+			typed[name] = updated
+		}
+
+		return typed, true
+	case []interface{}:
+		selector, rest, ok := splitSelector(path)
+		if !ok {
+			return value, false
+		}
+
+		for i, item := range typed {
+			if !selectorMatches(selector, i, item) {
+				continue
+			}
+
+			updated, found := updateValue(item, rest, update)
+			if !found {
+				return value, false
+			}
+
+			if updated == nil {
+				return append(typed[:i:i], typed[i+1:]...), true
+			}
+
+			typed[i] = updated
+
+			return typed, true
+		}
+	}
+
+	return value, false
+}
+
+// splitSelector splits the list selector at the start of a field path from the rest of it.
+func splitSelector(path string) (selector, rest string, ok bool) {
+	if path == "" || path[0] != '[' {
+		return "", "", false
+	}
+
+	for i, quoted := 1, false; i < len(path); i++ {
+		switch {
+		case quoted && path[i] == '\\':
+			i++
+		case path[i] == '"':
+			quoted = !quoted
+		case !quoted && path[i] == ']':
+			return path[1:i], path[i+1:], true
+		}
+	}
+
+	return "", "", false
+}
+
+// selectorMatches returns whether the item at index of a list matches a list selector, which
+// is an index, a value in the form =value or a set of keys in the form key=value,key=value.
+func selectorMatches(selector string, index int, item interface{}) bool {
+	if i, err := strconv.Atoi(selector); err == nil {
+		return i == index
+	}
+
+	if strings.HasPrefix(selector, "=") {
+		return jsonEqual(item, selector[1:])
+	}
+
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	for _, key := range splitKeys(selector) {
+		name := strings.SplitN(key, "=", 2)
+		if len(name) != 2 || !jsonEqual(fields[name[0]], name[1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitKeys splits the keys of a list selector, which are separated by commas outside of the
+// quoted values.
+func splitKeys(selector string) []string {
+	keys := []string{}
+
+	start := 0
+
+	for i, quoted := 0, false; i < len(selector); i++ {
+		switch {
+		case quoted && selector[i] == '\\':
+			i++
+		case selector[i] == '"':
+			quoted = !quoted
+		case !quoted && selector[i] == ',':
+			keys = append(keys, selector[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(keys, selector[start:])
+}
+
+// jsonEqual returns whether a value is equal to a value in JSON.
+func jsonEqual(value interface{}, raw string) bool {
+	var expected interface{}
+	if err := json.Unmarshal([]byte(raw), &expected); err != nil {
+		return false
+	}
+
+	left, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	right, err := json.Marshal(expected)
+	if err != nil {
+		return false
+	}
+
+	return string(left) == string(right)
+}
+`
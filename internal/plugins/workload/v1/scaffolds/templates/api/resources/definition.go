@@ -76,8 +76,14 @@ func Create{{ .UniqueName }} (
 
 	{{- .SourceCode }}
 
-	{{ if not $.Builder.IsClusterScoped }}
+	{{ if not (or $.Builder.IsClusterScoped .ClusterScoped) }}
+	{{- if .NamespaceField }}
+	if resourceObj.GetNamespace() == "" {
+		resourceObj.SetNamespace(parent.Namespace)
+	}
+	{{- else }}
 	resourceObj.SetNamespace(parent.Namespace)
+	{{- end }}
 	{{ end }}
 
 	resourceObjs = append(resourceObjs, resourceObj)
@@ -0,0 +1,210 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ machinery.Template = &Delete{}
+
+// Delete scaffolds the function which tears down the child resources of a workload when it
+// is deleted.
+type Delete struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
+}
+
+func (f *Delete) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		f.Resource.Group,
+		f.Resource.Version,
+		f.Builder.GetPackageName(),
+		"delete.go",
+	)
+
+	f.TemplateBody = deleteTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const deleteTemplate = `{{ .Boilerplate }}
+
+package {{ .Builder.GetPackageName }}
+
+import (
+	"context"
+	{{- if .Builder.GetDeleteWaves }}
+	"fmt"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	{{- end }}
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+	{{- if .Builder.IsComponent }}
+	{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }} "{{ .Repo }}/apis/{{ .Builder.GetCollection.Spec.API.Group }}/{{ .Builder.GetCollection.Spec.API.Version }}"
+	{{- end }}
+)
+
+const (
+	// DeletePolicyDelete deletes a child resource when the {{ .Resource.Kind }} is deleted.
+	DeletePolicyDelete = "delete"
+
+	// DeletePolicyOrphan leaves a child resource in the cluster when the {{ .Resource.Kind }} is
+	// deleted, without its owner reference to the {{ .Resource.Kind }}.
+	DeletePolicyOrphan = "orphan"
+
+	// DeletePolicyRetain leaves a child resource to garbage collection when the {{ .Resource.Kind }}
+	// is deleted.
+	DeletePolicyRetain = "retain"
+)
+{{- if .Builder.GetDeleteWaves }}
+
+// deleteResource is a child resource which is torn down with the given policy.
+type deleteResource struct {
+	create func(
+		*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+		{{- if .Builder.IsComponent }}
+		*{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+		{{- end }}
+	) ([]client.Object, error)
+	policy string
+}
+
+// deleteWaves are the waves of child resources in the order in which they are torn down.
+// Child resources with the retain policy are not torn down.
+var deleteWaves = [][]deleteResource{
+	{{- range .Builder.GetDeleteWaves }}
+	// wave {{ .Wave }}
+	{
+		{{- range .Resources }}
+		{create: {{ .CreateFunc }}, policy: DeletePolicy{{ .Policy | title }}},
+		{{- end }}
+	},
+	{{- end }}
+}
+{{- end }}
+
+// Teardown tears down the child resources of the {{ .Resource.Kind }} in reverse wave order, starting
+// with the highest wave, and returns whether each child resource has been torn down.  The child
+// resources of a wave are only torn down once those of the previous wave no longer exist.
+func Teardown(
+	ctx context.Context,
+	c client.Client,
+	parent *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{- if .Builder.IsComponent }}
+	collection *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{- end }}
+) (bool, error) {
+	{{- if .Builder.GetDeleteWaves }}
+	for _, wave := range deleteWaves {
+		complete := true
+
+		for _, resource := range wave {
+			resourceObjs, err := resource.create(parent{{ if .Builder.IsComponent }}, collection{{ end }})
+			if err != nil {
+				return false, fmt.Errorf("unable to create resources for teardown, %w", err)
+			}
+
+			for _, resourceObj := range resourceObjs {
+				done, err := teardownResource(ctx, c, parent, resourceObj, resource.policy)
+				if err != nil {
+					return false, err
+				}
+
+				complete = complete && done
+			}
+		}
+
+		if !complete {
+			return false, nil
+		}
+	}
+	{{- end }}
+
+	return true, nil
+}
+{{- if .Builder.GetDeleteWaves }}
+
+// teardownResource deletes or orphans a child resource and returns whether it has been torn
+// down.  A deleted child resource is torn down once it no longer exists.
+func teardownResource(
+	ctx context.Context,
+	c client.Client,
+	parent client.Object,
+	resourceObj client.Object,
+	policy string,
+) (bool, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(resourceObj.GetObjectKind().GroupVersionKind())
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(resourceObj), current); err != nil {
+		// the kind of the child resource no longer exists when its definition has been deleted
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("unable to get resource %s, %w", resourceObj.GetName(), err)
+	}
+
+	if policy == DeletePolicyOrphan {
+		return true, orphanResource(ctx, c, parent, current)
+	}
+
+	if current.GetDeletionTimestamp() != nil {
+		return false, nil
+	}
+
+	if err := c.Delete(ctx, current, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		if apierrs.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("unable to delete resource %s, %w", resourceObj.GetName(), err)
+	}
+
+	return false, nil
+}
+
+// orphanResource removes the owner reference to the parent from a child resource, so that
+// the child resource is not deleted by garbage collection once the parent is gone.
+func orphanResource(ctx context.Context, c client.Client, parent client.Object, current *unstructured.Unstructured) error {
+	owners := []metav1.OwnerReference{}
+
+	for _, owner := range current.GetOwnerReferences() {
+		if owner.UID != parent.GetUID() {
+			owners = append(owners, owner)
+		}
+	}
+
+	if len(owners) == len(current.GetOwnerReferences()) {
+		return nil
+	}
+
+	current.SetOwnerReferences(owners)
+
+	if err := c.Update(ctx, current); err != nil {
+		return fmt.Errorf("unable to orphan resource %s, %w", current.GetName(), err)
+	}
+
+	return nil
+}
+{{- end }}
+`
@@ -0,0 +1,397 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ machinery.Template = &Ready{}
+
+// Ready scaffolds the function which determines whether the child resources of a workload
+// are ready.
+type Ready struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
+
+	// template fields
+	ReadyKinds map[string]bool
+	HasChecks  bool
+}
+
+func (f *Ready) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		f.Resource.Group,
+		f.Resource.Version,
+		f.Builder.GetPackageName(),
+		"ready.go",
+	)
+
+	f.ReadyKinds = map[string]bool{}
+	for _, kind := range f.Builder.GetReadyKinds() {
+		f.ReadyKinds[kind] = true
+	}
+
+	f.HasChecks = len(f.ReadyKinds) > 0 || len(f.Builder.GetReadyConditions()) > 0
+
+	f.TemplateBody = readyTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const readyTemplate = `{{ .Boilerplate }}
+
+package {{ .Builder.GetPackageName }}
+
+import (
+	"context"
+	{{- if .HasChecks }}
+	"fmt"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	{{- if .ReadyKinds }}
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	{{- end }}
+	{{- if .Builder.GetReadyConditions }}
+	"k8s.io/client-go/util/jsonpath"
+	{{- end }}
+	{{- end }}
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+	{{- if .Builder.IsComponent }}
+	{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }} "{{ .Repo }}/apis/{{ .Builder.GetCollection.Spec.API.Group }}/{{ .Builder.GetCollection.Spec.API.Version }}"
+	{{- end }}
+)
+
+// CheckReady returns whether the child resources of the {{ .Resource.Kind }}, as they exist in the
+// cluster, are ready.  A child resource of a common kind is ready once it has rolled out,
+// e.g. a Deployment with each of its replicas updated and available.  A child resource
+// is also checked against the readiness conditions declared by its ready markers.
+func CheckReady(
+	ctx context.Context,
+	reader client.Reader,
+	parent *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{- if .Builder.IsComponent }}
+	collection *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{- end }}
+) (bool, error) {
+	{{- if .HasChecks }}
+	var resourceObjs []client.Object
+
+	var ready bool
+
+	var err error
+	{{- if .ReadyKinds }}
+
+	// check each child resource of a kind with a default readiness check
+	if resourceObjs, err = Generate(*parent{{ if .Builder.IsComponent }}, *collection{{ end }}); err != nil {
+		return false, fmt.Errorf("unable to create resources, %w", err)
+	}
+
+	if ready, err = resourcesAreReady(ctx, reader, resourceObjs); err != nil || !ready {
+		return false, err
+	}
+	{{- end }}
+	{{- range .Builder.GetReadyConditions }}
+
+	// {{ .Comment }}
+	if resourceObjs, err = {{ .CreateFunc }}(parent{{ if $.Builder.IsComponent }}, collection{{ end }}); err != nil {
+		return false, fmt.Errorf("unable to create resource for readiness condition, %w", err)
+	}
+
+	if ready, err = conditionIsMet(ctx, reader, resourceObjs, {{ printf "%q" .Path }}, {{ printf "%q" .Value }}); err != nil || !ready {
+		return false, err
+	}
+	{{- end }}
+	{{- end }}
+
+	return true, nil
+}
+{{- if .HasChecks }}
+
+// getCurrent returns a child resource as it exists in the cluster, or nil when it does not exist.
+func getCurrent(ctx context.Context, reader client.Reader, resourceObj client.Object) (*unstructured.Unstructured, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(resourceObj.GetObjectKind().GroupVersionKind())
+
+	if err := reader.Get(ctx, client.ObjectKeyFromObject(resourceObj), current); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to get resource %s, %w", resourceObj.GetName(), err)
+	}
+
+	return current, nil
+}
+{{- end }}
+{{- if .ReadyKinds }}
+
+// resourcesAreReady returns whether each child resource is ready.  A child resource which does
+// not exist is not ready.
+func resourcesAreReady(ctx context.Context, reader client.Reader, resourceObjs []client.Object) (bool, error) {
+	for _, resourceObj := range resourceObjs {
+		current, err := getCurrent(ctx, reader, resourceObj)
+		if err != nil || current == nil {
+			return false, err
+		}
+
+		if ready, err := isReady(ctx, reader, current); err != nil || !ready {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// isReady returns whether a child resource is ready.  A child resource of a kind without a
+// readiness check is always ready.
+func isReady(ctx context.Context, reader client.Reader, current *unstructured.Unstructured) (bool, error) {
+	switch current.GroupVersionKind().GroupKind() {
+	{{- if .ReadyKinds.Deployment }}
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		return deploymentIsReady(current), nil
+	{{- end }}
+	{{- if .ReadyKinds.StatefulSet }}
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		return statefulSetIsReady(current), nil
+	{{- end }}
+	{{- if .ReadyKinds.DaemonSet }}
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		return daemonSetIsReady(current), nil
+	{{- end }}
+	{{- if .ReadyKinds.Job }}
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		return jobIsReady(current)
+	{{- end }}
+	{{- if .ReadyKinds.PersistentVolumeClaim }}
+	case schema.GroupKind{Kind: "PersistentVolumeClaim"}:
+		phase, _, _ := unstructured.NestedString(current.Object, "status", "phase")
+
+		return phase == "Bound", nil
+	{{- end }}
+	{{- if .ReadyKinds.CustomResourceDefinition }}
+	case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+		return conditionIsTrue(current, "Established"), nil
+	{{- end }}
+	{{- if .ReadyKinds.Service }}
+	case schema.GroupKind{Kind: "Service"}:
+		return serviceIsReady(ctx, reader, current)
+	{{- end }}
+	}
+
+	return true, nil
+}
+{{- if or .ReadyKinds.Deployment .ReadyKinds.StatefulSet .ReadyKinds.DaemonSet }}
+
+// nestedInt64 returns the integer at path of a child resource, or def when it is not set.
+func nestedInt64(current *unstructured.Unstructured, def int64, path ...string) int64 {
+	value, found, err := unstructured.NestedInt64(current.Object, path...)
+	if err != nil || !found {
+		return def
+	}
+
+	return value
+}
+
+// generationIsObserved returns whether the latest generation of a child resource has been
+// observed by its controller.
+func generationIsObserved(current *unstructured.Unstructured) bool {
+	return nestedInt64(current, 0, "status", "observedGeneration") >= current.GetGeneration()
+}
+{{- end }}
+{{- if or .ReadyKinds.StatefulSet .ReadyKinds.DaemonSet }}
+
+// updateStrategy returns the type of the update strategy of a child resource.
+func updateStrategy(current *unstructured.Unstructured) string {
+	strategy, _, _ := unstructured.NestedString(current.Object, "spec", "updateStrategy", "type")
+
+	return strategy
+}
+{{- end }}
+{{- if .ReadyKinds.Deployment }}
+
+// deploymentIsReady returns whether the rollout of a Deployment is complete.
+func deploymentIsReady(current *unstructured.Unstructured) bool {
+	replicas := nestedInt64(current, 1, "spec", "replicas")
+	updated := nestedInt64(current, 0, "status", "updatedReplicas")
+
+	return generationIsObserved(current) &&
+		updated >= replicas &&
+		nestedInt64(current, 0, "status", "replicas") <= updated &&
+		nestedInt64(current, 0, "status", "availableReplicas") >= updated
+}
+{{- end }}
+{{- if .ReadyKinds.StatefulSet }}
+
+// statefulSetIsReady returns whether the rollout of a StatefulSet is complete.
+func statefulSetIsReady(current *unstructured.Unstructured) bool {
+	replicas := nestedInt64(current, 1, "spec", "replicas")
+
+	if !generationIsObserved(current) || nestedInt64(current, 0, "status", "readyReplicas") < replicas {
+		return false
+	}
+
+	if updateStrategy(current) == "OnDelete" {
+		return true
+	}
+
+	// only the replicas at or above the partition are updated by a partitioned rollout
+	partition := nestedInt64(current, 0, "spec", "updateStrategy", "rollingUpdate", "partition")
+	if partition > 0 {
+		return nestedInt64(current, 0, "status", "updatedReplicas") >= replicas-partition
+	}
+
+	currentRevision, _, _ := unstructured.NestedString(current.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(current.Object, "status", "updateRevision")
+
+	return currentRevision == updateRevision
+}
+{{- end }}
+{{- if .ReadyKinds.DaemonSet }}
+
+// daemonSetIsReady returns whether the rollout of a DaemonSet is complete.
+func daemonSetIsReady(current *unstructured.Unstructured) bool {
+	desired := nestedInt64(current, 0, "status", "desiredNumberScheduled")
+
+	if !generationIsObserved(current) || nestedInt64(current, 0, "status", "numberAvailable") < desired {
+		return false
+	}
+
+	if updateStrategy(current) == "OnDelete" {
+		return true
+	}
+
+	return nestedInt64(current, 0, "status", "updatedNumberScheduled") >= desired
+}
+{{- end }}
+{{- if .ReadyKinds.Job }}
+
+// jobIsReady returns whether a Job has succeeded.  An error is returned when the Job has failed.
+func jobIsReady(current *unstructured.Unstructured) (bool, error) {
+	if conditionIsTrue(current, "Failed") {
+		return false, fmt.Errorf("job %s has failed", current.GetName())
+	}
+
+	return conditionIsTrue(current, "Complete"), nil
+}
+{{- end }}
+{{- if or .ReadyKinds.Job .ReadyKinds.CustomResourceDefinition }}
+
+// conditionIsTrue returns whether the condition of a child resource with the given type has
+// a status of True.
+func conditionIsTrue(current *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(current.Object, "status", "conditions")
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if ok && fields["type"] == conditionType {
+			return fields["status"] == "True"
+		}
+	}
+
+	return false
+}
+{{- end }}
+{{- if .ReadyKinds.Service }}
+
+// serviceIsReady returns whether a Service has endpoints with at least one address.  A Service
+// without a selector, or of type ExternalName, has no endpoints managed by the cluster and
+// is always ready.
+func serviceIsReady(ctx context.Context, reader client.Reader, current *unstructured.Unstructured) (bool, error) {
+	serviceType, _, _ := unstructured.NestedString(current.Object, "spec", "type")
+	selector, _, _ := unstructured.NestedStringMap(current.Object, "spec", "selector")
+
+	if serviceType == "ExternalName" || len(selector) == 0 {
+		return true, nil
+	}
+
+	endpoints := &unstructured.Unstructured{}
+	endpoints.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Endpoints"})
+
+	if err := reader.Get(ctx, client.ObjectKeyFromObject(current), endpoints); err != nil {
+		if apierrs.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to get endpoints %s, %w", current.GetName(), err)
+	}
+
+	subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+
+	for _, subset := range subsets {
+		fields, ok := subset.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if addresses, ok := fields["addresses"].([]interface{}); ok && len(addresses) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+{{- end }}
+{{- end }}
+{{- if .Builder.GetReadyConditions }}
+
+// conditionIsMet returns whether the value at the JSONPath path of each child resource is
+// value, or is set when value is empty.
+func conditionIsMet(ctx context.Context, reader client.Reader, resourceObjs []client.Object, path, value string) (bool, error) {
+	for _, resourceObj := range resourceObjs {
+		current, err := getCurrent(ctx, reader, resourceObj)
+		if err != nil || current == nil {
+			return false, err
+		}
+
+		query := jsonpath.New(resourceObj.GetName()).AllowMissingKeys(true)
+		if err := query.Parse("{" + path + "}"); err != nil {
+			return false, fmt.Errorf("unable to parse readiness condition %s, %w", path, err)
+		}
+
+		results, err := query.FindResults(current.Object)
+		if err != nil {
+			return false, fmt.Errorf("unable to find %s of resource %s, %w", path, resourceObj.GetName(), err)
+		}
+
+		var found bool
+
+		for _, result := range results {
+			for _, match := range result {
+				if !match.IsValid() || !match.CanInterface() {
+					continue
+				}
+
+				if value != "" && fmt.Sprint(match.Interface()) != value {
+					return false, nil
+				}
+
+				found = true
+			}
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+{{- end }}
+`
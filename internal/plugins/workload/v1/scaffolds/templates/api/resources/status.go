@@ -0,0 +1,164 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package resources
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ machinery.Template = &Status{}
+
+// Status scaffolds the function which copies the status fields of a workload from its
+// child resources.
+type Status struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
+}
+
+func (f *Status) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		f.Resource.Group,
+		f.Resource.Version,
+		f.Builder.GetPackageName(),
+		"status.go",
+	)
+
+	f.TemplateBody = statusTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const statusTemplate = `{{ .Boilerplate }}
+
+package {{ .Builder.GetPackageName }}
+
+import (
+	"context"
+	{{- if .Builder.GetStatusFields }}
+	"encoding/json"
+	"fmt"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	{{- end }}
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+	{{- if .Builder.IsComponent }}
+	{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }} "{{ .Repo }}/apis/{{ .Builder.GetCollection.Spec.API.Group }}/{{ .Builder.GetCollection.Spec.API.Version }}"
+	{{- end }}
+)
+
+// UpdateStatus copies the status fields of the {{ .Resource.Kind }} from its child resources as
+// they exist in the cluster.  A status field is left unchanged when its child resource does
+// not exist or when the value of the child resource is not set.
+func UpdateStatus(
+	ctx context.Context,
+	reader client.Reader,
+	parent *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+	{{- if .Builder.IsComponent }}
+	collection *{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }}.{{ .Builder.GetCollection.Spec.API.Kind }},
+	{{- end }}
+) error {
+	{{- if .Builder.GetStatusFields }}
+	var resourceObjs []client.Object
+
+	var err error
+	{{- range .Builder.GetStatusFields }}
+
+	// copy the {{ .Name }} status field from {{ .Path }}
+	if resourceObjs, err = {{ .CreateFunc }}(parent{{ if $.Builder.IsComponent }}, collection{{ end }}); err != nil {
+		return fmt.Errorf("unable to create resource for status field {{ .Name }}, %w", err)
+	}
+
+	if err = copyStatus(ctx, reader, resourceObjs, &parent.Status.{{ .FieldName }}, {{ .PathArgs }}); err != nil {
+		return fmt.Errorf("unable to copy status field {{ .Name }}, %w", err)
+	}
+	{{- end }}
+	{{- end }}
+
+	return nil
+}
+{{- if .Builder.GetStatusFields }}
+
+// copyStatus copies the value at path of the child resources, as they exist in the cluster,
+// into target.  Each element of the path is either a field name or an array index.
+func copyStatus(
+	ctx context.Context,
+	reader client.Reader,
+	resourceObjs []client.Object,
+	target interface{},
+	path ...interface{},
+) error {
+	for _, resourceObj := range resourceObjs {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(resourceObj.GetObjectKind().GroupVersionKind())
+
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(resourceObj), current); err != nil {
+			if apierrs.IsNotFound(err) {
+				continue
+			}
+
+			return fmt.Errorf("unable to get resource %s, %w", resourceObj.GetName(), err)
+		}
+
+		value, found := statusValue(current.Object, path)
+		if !found {
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("unable to read value of resource %s, %w", resourceObj.GetName(), err)
+		}
+
+		if err := json.Unmarshal(data, target); err != nil {
+			return fmt.Errorf("unable to convert value of resource %s, %w", resourceObj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// statusValue returns the value at path of an object and whether the value was found.
+func statusValue(object interface{}, path []interface{}) (interface{}, bool) {
+	value := object
+
+	for _, element := range path {
+		switch key := element.(type) {
+		case string:
+			fields, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			if value, ok = fields[key]; !ok {
+				return nil, false
+			}
+		case int:
+			items, ok := value.([]interface{})
+			if !ok || key >= len(items) {
+				return nil, false
+			}
+
+			value = items[key]
+		}
+	}
+
+	return value, true
+}
+{{- end }}
+`
@@ -26,6 +26,14 @@ type Types struct {
 
 	// input fields
 	Builder workloadv1.WorkloadAPIBuilder
+
+	// StorageVersion marks the version as the one stored in the cluster.  This is set
+	// when the kind is served at multiple versions.
+	StorageVersion bool
+
+	// template fields
+	ResourceMarker string
+	PrinterColumns []string
 }
 
 // SetTemplateDefaults implements file.Template.
@@ -37,12 +45,35 @@ func (f *Types) SetTemplateDefaults() error {
 		fmt.Sprintf("%s_types.go", strings.ToLower(f.Resource.Kind)),
 	)
 
+	f.ResourceMarker = resourceMarker(f.Builder)
+	f.PrinterColumns = f.Builder.GetAPISpecFields().GeneratePrinterColumns()
+
 	f.TemplateBody = typesTemplate
 	f.IfExistsAction = machinery.OverwriteFile
 
 	return nil
 }
 
+// resourceMarker returns the arguments of the kubebuilder resource marker of a workload,
+// which sets the scope, short names and categories of its custom resource definition.
+func resourceMarker(builder workloadv1.WorkloadAPIBuilder) string {
+	args := []string{}
+
+	if builder.IsClusterScoped() {
+		args = append(args, "scope=Cluster")
+	}
+
+	if shortNames := builder.GetAPIShortNames(); len(shortNames) > 0 {
+		args = append(args, "shortName="+strings.Join(shortNames, ";"))
+	}
+
+	if categories := builder.GetAPICategories(); len(categories) > 0 {
+		args = append(args, "categories="+strings.Join(categories, ";"))
+	}
+
+	return strings.Join(args, ",")
+}
+
 func (*Types) GetFuncMap() template.FuncMap {
 	return utils.ContainsStringHelper()
 }
@@ -53,9 +84,11 @@ package {{ .Resource.Version }}
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/nukleros/operator-builder-tools/pkg/status"
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -69,10 +102,26 @@ import (
 	{{ end }}
 )
 
+// imports needed by code within the user regions of this file are preserved here.
+//+operator-builder:user:begin:imports
+//+operator-builder:user:end:imports
+
 var ErrUnableToConvert{{ .Resource.Kind }} = errors.New("unable to convert to {{ .Resource.Kind }}")
 
-// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+const (
+	// {{ .Resource.Kind }}PausedAnnotation pauses the reconciliation of a {{ .Resource.Kind }} when set to "true".
+	{{ .Resource.Kind }}PausedAnnotation = "{{ .Resource.QualifiedGroup }}/paused"
+
+	// {{ .Resource.Kind }}OwnerNameLabel and {{ .Resource.Kind }}OwnerNamespaceLabel label the child resources
+	// of a {{ .Resource.Kind }} which cannot have an owner reference to it, as they are cluster-scoped or in
+	// another namespace, so that they are watched by the controller.
+	{{ .Resource.Kind }}OwnerNameLabel      = "{{ lower .Resource.Kind }}.{{ .Resource.QualifiedGroup }}/owner-name"
+	{{ .Resource.Kind }}OwnerNamespaceLabel = "{{ lower .Resource.Kind }}.{{ .Resource.QualifiedGroup }}/owner-namespace"
+)
+
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+// NOTE: this file is overwritten when the api is regenerated with --force.  Only code within the
+// +operator-builder:user regions is preserved.
 
 {{ .Builder.GetAPISpecFields.GenerateAPISpec .Resource.Kind }}
 
@@ -80,18 +129,46 @@ var ErrUnableToConvert{{ .Resource.Kind }} = errors.New("unable to convert to {{
 type {{ .Resource.Kind }}Status struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+	// Fields added within the user region below are preserved when the api is regenerated.
+	//+operator-builder:user:begin:status
+	//+operator-builder:user:end:status
 
 	Created               bool                       ` + "`" + `json:"created,omitempty"` + "`" + `
 	DependenciesSatisfied bool                       ` + "`" + `json:"dependenciesSatisfied,omitempty"` + "`" + `
-	Conditions            []*status.PhaseCondition   ` + "`" + `json:"conditions,omitempty"` + "`" + `
-	Resources             []*status.ChildResource    ` + "`" + `json:"resources,omitempty"` + "`" + `
+
+	// ObservedGeneration is the generation of the {{ .Resource.Kind }} which was most recently
+	// reconciled to completion.
+	ObservedGeneration int64 ` + "`" + `json:"observedGeneration,omitempty"` + "`" + `
+
+	// Conditions are the standard conditions of the {{ .Resource.Kind }}, which summarize the
+	// state of the phase conditions.
+	// +listType=map
+	// +listMapKey=type
+	Conditions      []metav1.Condition         ` + "`" + `json:"conditions,omitempty"` + "`" + `
+	PhaseConditions []*status.PhaseCondition   ` + "`" + `json:"phaseConditions,omitempty"` + "`" + `
+	Resources       []*status.ChildResource    ` + "`" + `json:"resources,omitempty"` + "`" + `
+	{{- range .Builder.GetStatusFields }}
+{{ range .Comments }}
+	// {{ . }}
+	{{- end }}
+	{{ .FieldName }} {{ .Type }} ` + "`" + `json:"{{ .Name }},omitempty"` + "`" + `
+	{{- end }}
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-{{- if .Builder.IsClusterScoped }}
-// +kubebuilder:resource:scope=Cluster
-{{ end }}
+{{- if .StorageVersion }}
+// +kubebuilder:storageversion
+{{- end }}
+{{- if .ResourceMarker }}
+// +kubebuilder:resource:{{ .ResourceMarker }}
+{{- end }}
+// +kubebuilder:printcolumn:name="Created",type=boolean,JSONPath=".status.created"
+// +kubebuilder:printcolumn:name="Dependencies",type=boolean,JSONPath=".status.dependenciesSatisfied"
+{{- range .PrinterColumns }}
+// {{ . }}
+{{- end }}
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 
 // {{ .Resource.Kind }} is the Schema for the {{ .Resource.Plural }} API.
 type {{ .Resource.Kind }} struct {
@@ -117,9 +194,14 @@ func (component *{{ .Resource.Kind }}) GetReadyStatus() bool {
 	return component.Status.Created
 }
 
-// SetReadyStatus sets the ready status for a component.
+// SetReadyStatus sets the ready status for a component.  The ready status is set once
+// each phase has completed, so the current generation of the component is observed.
 func (component *{{ .Resource.Kind }}) SetReadyStatus(ready bool) {
 	component.Status.Created = ready
+
+	if ready {
+		component.Status.ObservedGeneration = component.Generation
+	}
 }
 
 // GetDependencyStatus returns the dependency status for a component.
@@ -134,21 +216,113 @@ func (component *{{ .Resource.Kind }}) SetDependencyStatus(dependencyStatus bool
 
 // GetPhaseConditions returns the phase conditions for a component.
 func (component *{{ .Resource.Kind }}) GetPhaseConditions() []*status.PhaseCondition {
-	return component.Status.Conditions
+	return component.Status.PhaseConditions
 }
 
-// SetPhaseCondition sets the phase conditions for a component.
+// SetPhaseCondition sets the phase conditions for a component.  The standard conditions
+// are updated along with the phase conditions, as both are persisted together.
 func (component *{{ .Resource.Kind }}) SetPhaseCondition(condition *status.PhaseCondition) {
 	for i, currentCondition := range component.GetPhaseConditions() {
 		if currentCondition.Phase == condition.Phase {
-			component.Status.Conditions[i] = condition
+			component.Status.PhaseConditions[i] = condition
+			component.setReadyCondition()
 
 			return
 		}
 	}
 
 	// phase not found, lets add it to the list.
-	component.Status.Conditions = append(component.Status.Conditions, condition)
+	component.Status.PhaseConditions = append(component.Status.PhaseConditions, condition)
+	component.setReadyCondition()
+}
+
+// setReadyCondition sets the standard Ready condition for a component.  The component is
+// ready once each phase has completed for its current generation, is not ready when a
+// phase has failed and is otherwise still being reconciled.
+func (component *{{ .Resource.Kind }}) setReadyCondition() {
+	ready := metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: component.Generation,
+		Reason:             "Reconciled",
+		Message:            "each phase has completed",
+	}
+
+	for _, condition := range component.GetPhaseConditions() {
+		if condition.State == status.PhaseStateFailed {
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = "PhaseFailed"
+			ready.Message = fmt.Sprintf("%s phase: %s", condition.Phase, condition.Message)
+
+			break
+		}
+
+		if condition.State != status.PhaseStateComplete && ready.Status == metav1.ConditionTrue {
+			ready.Status = metav1.ConditionUnknown
+			ready.Reason = "Reconciling"
+			ready.Message = fmt.Sprintf("waiting for the %s phase", condition.Phase)
+		}
+	}
+
+	if ready.Status == metav1.ConditionTrue &&
+		(!component.Status.Created || component.Status.ObservedGeneration != component.Generation) {
+		ready.Status = metav1.ConditionUnknown
+		ready.Reason = "Reconciling"
+		ready.Message = fmt.Sprintf("reconciling generation %d", component.Generation)
+	}
+
+	meta.SetStatusCondition(&component.Status.Conditions, ready)
+}
+
+{{ if .Builder.HasPausedField -}}
+// IsPaused returns whether the reconciliation of a component is paused, either by its paused
+// annotation or by the paused field of its spec, along with the reason.
+{{ else -}}
+// IsPaused returns whether the reconciliation of a component is paused by its paused annotation,
+// along with the reason.
+{{ end -}}
+func (component *{{ .Resource.Kind }}) IsPaused() (bool, string) {
+	if component.GetAnnotations()[{{ .Resource.Kind }}PausedAnnotation] == "true" {
+		return true, "PausedByAnnotation"
+	}
+	{{- if .Builder.HasPausedField }}
+
+	if component.Spec.Paused {
+		return true, "PausedBySpec"
+	}
+	{{- end }}
+
+	return false, "Resumed"
+}
+
+// SetPausedCondition sets the Paused condition for a component and returns whether its status
+// changed.  The condition is only added once a component has been paused.
+func (component *{{ .Resource.Kind }}) SetPausedCondition(paused bool, reason string) bool {
+	condition := metav1.Condition{
+		Type:               "Paused",
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: component.Generation,
+		Reason:             reason,
+		Message:            "reconciliation has resumed",
+	}
+
+	if paused {
+		condition.Status = metav1.ConditionTrue
+		condition.Message = "reconciliation is paused"
+	}
+
+	existing := meta.FindStatusCondition(component.Status.Conditions, condition.Type)
+	if existing == nil && !paused {
+		return false
+	}
+
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason {
+		return false
+	}
+
+	meta.SetStatusCondition(&component.Status.Conditions, condition)
+
+	return true
 }
 
 // GetResources returns the child resource status for a component.
@@ -193,4 +367,8 @@ func (*{{ .Resource.Kind }}) GetWorkloadGVK() schema.GroupVersionKind {
 func init() {
 	SchemeBuilder.Register(&{{ .Resource.Kind }}{}, &{{ .Resource.Kind }}List{})
 }
+
+// additional methods for the api type are preserved here.
+//+operator-builder:user:begin:methods
+//+operator-builder:user:end:methods
 `
@@ -0,0 +1,115 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ machinery.Template = &Webhook{}
+
+// Webhook scaffolds the file that registers the webhooks of a kind with the manager and,
+// when enabled for the workload, validates the kind on admission.
+type Webhook struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
+
+	// template fields
+	QualifiedGroupWithDash string
+}
+
+// SetTemplateDefaults implements file.Template.
+func (f *Webhook) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"apis",
+		f.Resource.Group,
+		f.Resource.Version,
+		fmt.Sprintf("%s_webhook.go", strings.ToLower(f.Resource.Kind)),
+	)
+
+	f.QualifiedGroupWithDash = strings.ReplaceAll(f.Resource.QualifiedGroup(), ".", "-")
+
+	f.TemplateBody = webhookTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const webhookTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	{{- if .Builder.HasWebhooks }}
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	{{- end }}
+)
+
+// imports needed by code within the user regions of this file are preserved here.
+//+operator-builder:user:begin:imports
+//+operator-builder:user:end:imports
+
+// NOTE: this file is overwritten when the api is regenerated.  Only code within the
+// +operator-builder:user regions is preserved.
+
+// SetupWebhookWithManager registers the webhooks for {{ .Resource.Kind }} with the manager.
+func (component *{{ .Resource.Kind }}) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(component).Complete()
+}
+{{- if .Builder.HasWebhooks }}
+
+//+kubebuilder:webhook:path=/validate-{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=false,failurePolicy=fail,sideEffects=None,groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=v{{ lower .Resource.Kind }}.{{ .Resource.QualifiedGroup }},admissionReviewVersions=v1
+
+var _ webhook.Validator = &{{ .Resource.Kind }}{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (component *{{ .Resource.Kind }}) ValidateCreate() error {
+	return component.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (component *{{ .Resource.Kind }}) ValidateUpdate(old runtime.Object) error {
+	return component.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (component *{{ .Resource.Kind }}) ValidateDelete() error {
+	return nil
+}
+
+// validate validates the rules between fields of the {{ .Resource.Kind }} which cannot be
+// expressed in the OpenAPI schema of the custom resource definition.
+func (component *{{ .Resource.Kind }}) validate() error {
+	var errs field.ErrorList
+	{{- with .Builder.GetAPISpecFields.GenerateValidation "component" }}
+
+	{{ . }}
+	{{- end }}
+
+	//+operator-builder:user:begin:validate
+	//+operator-builder:user:end:validate
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("{{ .Resource.Kind }}").GroupKind(), component.Name, errs)
+}
+{{- end }}
+`
@@ -0,0 +1,55 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package kdefault
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &ManagerWebhookPatch{}
+
+// ManagerWebhookPatch scaffolds a file that patches the manager to serve webhooks using
+// the serving certificate.
+type ManagerWebhookPatch struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements file.Template.
+func (f *ManagerWebhookPatch) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "default", "manager_webhook_patch.yaml")
+	}
+
+	f.TemplateBody = managerWebhookPatchTemplate
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const managerWebhookPatchTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller-manager
+  namespace: system
+spec:
+  template:
+    spec:
+      containers:
+      - name: manager
+        ports:
+        - containerPort: 9443
+          name: webhook-server
+          protocol: TCP
+        volumeMounts:
+        - mountPath: /tmp/k8s-webhook-server/serving-certs
+          name: cert
+          readOnly: true
+      volumes:
+      - name: cert
+        secret:
+          defaultMode: 420
+          secretName: webhook-server-cert
+`
@@ -0,0 +1,40 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package kdefault
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &WebhookCAInjectionPatch{}
+
+// WebhookCAInjectionPatch scaffolds a file that patches the webhook configurations so that
+// cert-manager injects the certificate authority of the serving certificate.
+type WebhookCAInjectionPatch struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements file.Template.
+func (f *WebhookCAInjectionPatch) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "default", "webhookcainjection_patch.yaml")
+	}
+
+	f.TemplateBody = webhookCAInjectionPatchTemplate
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const webhookCAInjectionPatchTemplate = `# This patch add annotation to admission webhook config and
+# the variables $(CERTIFICATE_NAMESPACE) and $(CERTIFICATE_NAME) will be substituted by kustomize.
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: validating-webhook-configuration
+  annotations:
+    cert-manager.io/inject-ca-from: $(CERTIFICATE_NAMESPACE)/$(CERTIFICATE_NAME)
+`
@@ -0,0 +1,114 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var (
+	_ machinery.Template = &Config{}
+	_ machinery.Inserter = &ConfigUpdater{}
+)
+
+const workloadsMarker = "managerconfigworkloads"
+
+var defaultConfigPath = filepath.Join("config", "manager", "controller_manager_config.yaml")
+
+// Config scaffolds a sample of the controller manager config of the project, which is loaded
+// by the manager from its config file.
+type Config struct {
+	machinery.TemplateMixin
+	machinery.DomainMixin
+	machinery.RepositoryMixin
+}
+
+func (f *Config) SetTemplateDefaults() error {
+	f.Path = defaultConfigPath
+
+	f.TemplateBody = fmt.Sprintf(configTemplate,
+		machinery.NewMarkerFor(f.Path, workloadsMarker),
+	)
+
+	// replace the generic config of the manager with the config of the project
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+// ConfigUpdater updates the sample controller manager config with the settings of the
+// controller of a workload, which are commented out so that they do not override the
+// settings of the workload config until they are changed.
+type ConfigUpdater struct {
+	machinery.ResourceMixin
+
+	// MaxConcurrentReconciles is the number of workloads which the controller reconciles at
+	// once, as it is set by the workload config.
+	MaxConcurrentReconciles int
+}
+
+// GetPath implements file.Builder.
+func (*ConfigUpdater) GetPath() string {
+	return defaultConfigPath
+}
+
+// GetIfExistsAction implements file.Builder.
+func (*ConfigUpdater) GetIfExistsAction() machinery.IfExistsAction {
+	return machinery.OverwriteFile
+}
+
+// GetMarkers implements file.Inserter.
+func (f *ConfigUpdater) GetMarkers() []machinery.Marker {
+	return []machinery.Marker{
+		machinery.NewMarkerFor(defaultConfigPath, workloadsMarker),
+	}
+}
+
+const workloadCodeFragment = `  # %s:
+  #   maxConcurrentReconciles: %d
+`
+
+// GetCodeFragments implements file.Inserter.
+func (f *ConfigUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
+	maxConcurrentReconciles := f.MaxConcurrentReconciles
+	if maxConcurrentReconciles == 0 {
+		maxConcurrentReconciles = 1
+	}
+
+	return machinery.CodeFragmentsMap{
+		machinery.NewMarkerFor(defaultConfigPath, workloadsMarker): []string{
+			fmt.Sprintf(workloadCodeFragment, f.Resource.Kind, maxConcurrentReconciles),
+		},
+	}
+}
+
+const configTemplate = `apiVersion: config.{{ .Domain }}/v1alpha1
+kind: ControllerManagerConfig
+health:
+  healthProbeBindAddress: :8081
+metrics:
+  bindAddress: 127.0.0.1:8080
+webhook:
+  port: 9443
+leaderElection:
+  leaderElect: true
+  resourceName: {{ hashFNV .Repo }}.{{ .Domain }}
+# the namespaces which are watched by the manager, which watches all namespaces when
+# there are none.  The --watch-namespaces flag takes precedence over the namespaces.
+namespaces: []
+# the settings of the controller of each workload by its kind, which override the settings
+# of the workload config.  Uncomment the settings of a kind to change them, e.g. with the
+# requeue policy of a phase:
+#
+#     requeue:
+#       Check-Ready:
+#         interval: 10s
+#         maxInterval: 5m
+#         maxRetries: 20
+workloads:
+%s
+`
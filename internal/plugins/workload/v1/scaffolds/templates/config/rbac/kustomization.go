@@ -0,0 +1,69 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package rbac
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+)
+
+var (
+	_ machinery.Template = &Kustomization{}
+	_ machinery.Inserter = &Kustomization{}
+)
+
+// Kustomization scaffolds a file that defines the kustomization scheme for the namespaced
+// rbac folder, which holds the roles and role bindings of the controllers of namespace-scoped
+// workloads.
+type Kustomization struct {
+	machinery.TemplateMixin
+	machinery.ResourceMixin
+}
+
+// SetTemplateDefaults implements file.Template.
+func (f *Kustomization) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "rbac", "namespaced", "kustomization.yaml")
+	}
+
+	f.TemplateBody = fmt.Sprintf(kustomizationTemplate,
+		machinery.NewMarkerFor(f.Path, roleMarker),
+	)
+
+	return nil
+}
+
+const roleMarker = "rbackustomizerole"
+
+// GetMarkers implements file.Inserter.
+func (f *Kustomization) GetMarkers() []machinery.Marker {
+	return []machinery.Marker{
+		machinery.NewMarkerFor(f.Path, roleMarker),
+	}
+}
+
+const roleCodeFragment = `- %s_%s_role.yaml
+`
+
+// GetCodeFragments implements file.Inserter.
+func (f *Kustomization) GetCodeFragments() machinery.CodeFragmentsMap {
+	return machinery.CodeFragmentsMap{
+		machinery.NewMarkerFor(f.Path, roleMarker): []string{
+			fmt.Sprintf(roleCodeFragment, f.Resource.Group, utils.ToFileName(f.Resource.Kind)),
+		},
+	}
+}
+
+const kustomizationTemplate = `# This kustomization.yaml holds a role and role binding for the controller of each
+# namespace-scoped kind.  They replace the cluster role and cluster role binding of the manager
+# when it only watches the namespaces of its --watch-namespaces flag.  They are selected in
+# config/rbac/kustomization.yaml for the namespace of the manager, and are deployed to the other
+# watched namespaces with 'make deploy-namespaced-rbac WATCH_NAMESPACES=tenant-a,tenant-b'.
+resources:
+%s
+`
@@ -0,0 +1,112 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package rbac
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ machinery.Template = &Role{}
+
+// Role scaffolds the role with the namespaced rules of the controller of a namespace-scoped
+// workload, and its role binding to the manager, which replace the cluster role of the manager
+// in each namespace that it watches when cluster-wide RBAC is not used.
+type Role struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
+}
+
+func (f *Role) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"config",
+		"rbac",
+		"namespaced",
+		fmt.Sprintf("%s_%s_role.yaml", f.Resource.Group, utils.ToFileName(f.Resource.Kind)),
+	)
+
+	f.TemplateBody = roleTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const roleTemplate = `# NOTE: this file is regenerated from the workload config and manifests.  Do not edit.
+# The rules for the resources of cluster-scoped kinds are omitted, as a role may not grant them.
+# The service account of the manager is named with the name prefix and namespace of
+# config/default, as the role binding is also deployed to namespaces other than its own.
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: {{ .Resource.Group }}-{{ lower .Resource.Kind }}-namespaced-role
+rules:
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}/status
+  verbs:
+  - get
+  - update
+  - patch
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}/finalizers
+  verbs:
+  - update
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - create
+  - patch
+{{- range .Builder.GetRBACRules }}
+{{- if not .IsClusterScoped }}
+- apiGroups:
+  - {{ if eq .Group "core" }}""{{ else }}{{ .Group }}{{ end }}
+  resources:
+  - {{ .Resource }}
+  verbs:
+  {{- range .Verbs }}
+  - {{ . }}
+  {{- end }}
+{{- end }}
+{{- end }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: {{ .Resource.Group }}-{{ lower .Resource.Kind }}-namespaced-rolebinding
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: {{ .Resource.Group }}-{{ lower .Resource.Kind }}-namespaced-role
+subjects:
+- kind: ServiceAccount
+  name: {{ .ProjectName }}-controller-manager
+  namespace: {{ .ProjectName }}-system
+`
@@ -0,0 +1,36 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package rbac
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &WatchNamespace{}
+
+// WatchNamespace scaffolds the kustomization which deploys the namespaced roles of the manager
+// to a namespace, other than its own, which the manager watches.
+type WatchNamespace struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+}
+
+func (f *WatchNamespace) SetTemplateDefaults() error {
+	f.Path = filepath.Join("config", "rbac", "watch-namespace", "kustomization.yaml")
+
+	f.TemplateBody = watchNamespaceTemplate
+
+	return nil
+}
+
+const watchNamespaceTemplate = `# This kustomization.yaml deploys the roles and role bindings of the namespaced folder, named
+# with the name prefix of config/default, to a namespace which the manager watches.  The namespace
+# is set when it is applied, e.g. with 'make deploy-namespaced-rbac WATCH_NAMESPACES=tenant-a'.
+namePrefix: {{ .ProjectName }}-
+
+resources:
+- ../namespaced
+`
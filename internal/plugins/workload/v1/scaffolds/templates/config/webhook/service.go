@@ -0,0 +1,42 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Service{}
+
+// Service scaffolds a file that defines the service which exposes the webhook server.
+type Service struct {
+	machinery.TemplateMixin
+}
+
+// SetTemplateDefaults implements file.Template.
+func (f *Service) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join("config", "webhook", "service.yaml")
+	}
+
+	f.TemplateBody = serviceTemplate
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const serviceTemplate = `apiVersion: v1
+kind: Service
+metadata:
+  name: webhook-service
+  namespace: system
+spec:
+  ports:
+    - port: 443
+      targetPort: 9443
+  selector:
+    control-plane: controller-manager
+`
@@ -24,6 +24,12 @@ type Controller struct {
 
 	// input fields
 	Builder workloadv1.WorkloadAPIBuilder
+
+	// template fields
+	OwnedKinds          []workloadv1.OwnershipRule
+	LabeledKinds        []workloadv1.OwnershipRule
+	OtherNamespaceKinds []workloadv1.OwnershipRule
+	StatusKinds         []workloadv1.OwnershipRule
 }
 
 func (f *Controller) SetTemplateDefaults() error {
@@ -36,9 +42,45 @@ func (f *Controller) SetTemplateDefaults() error {
 	f.TemplateBody = controllerTemplate
 	f.IfExistsAction = machinery.OverwriteFile
 
+	f.setWatchedKinds()
+
 	return nil
 }
 
+// WatchesLabels determines if the controller watches any child resources by their labels.
+func (f *Controller) WatchesLabels() bool {
+	return len(f.LabeledKinds) > 0 || len(f.OtherNamespaceKinds) > 0
+}
+
+// setWatchedKinds sets the kinds built into Kubernetes of the child resources of a workload
+// which are watched by their owner references, those which are watched by their labels, as
+// they are cluster-scoped and the workload is not, and those of which the status is watched.
+// The kinds of child resources which may be placed in another namespace than the workload are
+// watched by both their owner references and their labels.
+func (f *Controller) setWatchedKinds() {
+	for _, rule := range *f.Builder.GetOwnershipRules() {
+		if !rule.CoreAPI {
+			continue
+		}
+
+		switch {
+		case f.Builder.IsClusterScoped():
+			f.OwnedKinds = append(f.OwnedKinds, rule)
+		case rule.ClusterScoped:
+			f.LabeledKinds = append(f.LabeledKinds, rule)
+		case rule.OtherNamespaces:
+			f.OwnedKinds = append(f.OwnedKinds, rule)
+			f.OtherNamespaceKinds = append(f.OtherNamespaceKinds, rule)
+		default:
+			f.OwnedKinds = append(f.OwnedKinds, rule)
+		}
+
+		if rule.WatchStatus {
+			f.StatusKinds = append(f.StatusKinds, rule)
+		}
+	}
+}
+
 //nolint: lll
 const controllerTemplate = `{{ .Boilerplate }}
 
@@ -50,16 +92,34 @@ import (
 	"errors"
 	{{- end }}
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
 	"github.com/nukleros/operator-builder-tools/pkg/controller/predicates"
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	{{- if .WatchesLabels }}
+	"k8s.io/apimachinery/pkg/types"
+	{{- end }}
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	{{- if .WatchesLabels }}
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	{{- end }}
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	{{- if .WatchesLabels }}
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	{{- end }}
 
 	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
 	{{ if .Builder.IsComponent -}}
@@ -68,10 +128,24 @@ import (
 	{{- if .Builder.HasChildResources -}}
 	"{{ .Resource.Path }}/{{ .Builder.GetPackageName }}"
 	{{ end -}}
+	"{{ .Repo }}/internal/config"
 	"{{ .Repo }}/internal/dependencies"
+	"{{ .Repo }}/internal/metrics"
 	"{{ .Repo }}/internal/mutate"
+	"{{ .Repo }}/internal/requeue"
+	{{- if .LabeledKinds }}
+	"{{ .Repo }}/internal/scope"
+	{{- end }}
+	"{{ .Repo }}/internal/tracing"
 )
 
+// NOTE: this file is overwritten when the api is regenerated with --force.  Only code within the
+// +operator-builder:user regions is preserved.
+
+// imports needed by code within the user regions of this file are preserved here.
+//+operator-builder:user:begin:imports
+//+operator-builder:user:end:imports
+
 // {{ .Resource.Kind }}Reconciler reconciles a {{ .Resource.Kind }} object.
 type {{ .Resource.Kind }}Reconciler struct {
 	client.Client
@@ -81,7 +155,10 @@ type {{ .Resource.Kind }}Reconciler struct {
 	Events       record.EventRecorder
 	FieldManager string
 	Watches      []client.Object
-	Phases       *phases.Registry
+	Phases       *requeue.Registry
+
+	//+operator-builder:user:begin:fields
+	//+operator-builder:user:end:fields
 }
 
 func New{{ .Resource.Kind }}Reconciler(mgr ctrl.Manager) *{{ .Resource.Kind }}Reconciler {
@@ -92,30 +169,42 @@ func New{{ .Resource.Kind }}Reconciler(mgr ctrl.Manager) *{{ .Resource.Kind }}Re
 		FieldManager: "{{ .Resource.Kind }}-reconciler",
 		Log:          ctrl.Log.WithName("controllers").WithName("{{ .Resource.Group }}").WithName("{{ .Resource.Kind }}"),
 		Watches:      []client.Object{},
-		Phases:       &phases.Registry{},
+		Phases: &requeue.Registry{
+			Policies: map[string]requeue.Policy{
+				{{- range .Builder.GetRequeueRules }}
+				"{{ .Phase }}": {
+					Interval: {{ .IntervalCode }},
+					{{- if .MaxInterval }}
+					MaxInterval: {{ .MaxIntervalCode }},
+					{{- end }}
+					{{- if .MaxRetries }}
+					MaxRetries: {{ .MaxRetries }},
+					{{- end }}
+				},
+				{{- end }}
+			},
+		},
 	}
 }
 
-// +kubebuilder:rbac:groups={{ .Resource.Group }}.{{ .Resource.Domain }},resources={{ .Resource.Plural }},verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups={{ .Resource.Group }}.{{ .Resource.Domain }},resources={{ .Resource.Plural }}/status,verbs=get;update;patch
-{{ range .Builder.GetRBACRules -}}
-// +kubebuilder:rbac:groups={{ .Group }},resources={{ .Resource }},verbs={{ .VerbString }}
-{{ end }}
-
-// Until Webhooks are implemented we need to list and watch namespaces to ensure
-// they are available before deploying resources,
-// See:
-//   - https://github.com/vmware-tanzu-labs/operator-builder/issues/141
-//   - https://github.com/vmware-tanzu-labs/operator-builder/issues/162
-
-// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=list;watch
-
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.2/pkg/reconcile
 func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "{{ .Resource.Kind }}", request)
+
+	result, err := r.reconcile(ctx, request)
+
+	tracing.End(span, err)
+
+	return result, err
+}
+
+// reconcile reconciles a component within the span of its reconciliation, which is the parent
+// of the spans of its phases as it is carried by the context of the workload request.
+func (r *{{ .Resource.Kind }}Reconciler) reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	req, err := r.NewRequest(ctx, request)
 	if err != nil {
 		{{- if .Builder.IsComponent }}
@@ -127,16 +216,61 @@ func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, request
 		if !apierrs.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
-		
+
+		metrics.Forget("{{ .Resource.Kind }}", request.Namespace, request.Name)
+		r.Phases.Forget(request.Namespace, request.Name)
+
 		return ctrl.Result{}, nil
 	}
 
+	// skip the reconciliation of a paused component until it is resumed
+	if paused, err := r.Pause(req); paused || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := phases.RegisterDeleteHooks(r, req); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// execute the phases
-	return r.Phases.HandleExecution(r, req)
+	// execute the phases and record whether the component is ready
+	result, err := r.Phases.HandleExecution(r, req)
+
+	metrics.SetReady(req.Workload)
+
+	return result, err
+}
+
+// Pause returns whether the reconciliation of a component is paused, and records when it is
+// paused or resumed.  A component which is being deleted is not paused, so that its deletion
+// is not blocked.
+func (r *{{ .Resource.Kind }}Reconciler) Pause(req *workload.Request) (bool, error) {
+	component, ok := req.Workload.(*{{ .Resource.ImportAlias }}.{{ .Resource.Kind }})
+	if !ok {
+		return false, fmt.Errorf("%w; unable to check whether workload is paused", {{ .Resource.ImportAlias }}.ErrUnableToConvert{{ .Resource.Kind }})
+	}
+
+	paused, reason := component.IsPaused()
+	if !component.GetDeletionTimestamp().IsZero() {
+		paused, reason = false, "Deleting"
+	}
+
+	if !component.SetPausedCondition(paused, reason) {
+		return paused, nil
+	}
+
+	if paused {
+		req.Log.Info("reconciliation is paused", "reason", reason)
+		r.Events.Event(component, corev1.EventTypeNormal, "Paused", "reconciliation is paused")
+	} else {
+		req.Log.Info("reconciliation has resumed")
+		r.Events.Event(component, corev1.EventTypeNormal, "Resumed", "reconciliation has resumed")
+	}
+
+	if err := r.Status().Update(req.Context, component); err != nil {
+		return paused, fmt.Errorf("unable to update paused condition, %w", err)
+	}
+
+	return paused, nil
 }
 
 func (r *{{ .Resource.Kind }}Reconciler) NewRequest(ctx context.Context, request ctrl.Request) (*workload.Request, error) {
@@ -311,10 +445,77 @@ func (r *{{ .Resource.Kind }}Reconciler) SetWatch(watch client.Object) {
 	r.Watches = append(r.Watches, watch)
 }
 
+// ApplyResources applies the child resources of a component with server-side apply and reports
+// the fields which were changed by other field managers as drift.  It is registered as a create
+// and update phase and therefore has the signature of a phase.
+func (r *{{ .Resource.Kind }}Reconciler) ApplyResources(_ workload.Reconciler, req *workload.Request) (bool, error) {
+	{{- if .Builder.HasChildResources }}
+	component, {{ if .Builder.IsComponent }}collection,{{ end }} err := {{ .Builder.GetPackageName }}.ConvertWorkload(req.Workload{{ if .Builder.IsComponent }}, req.Collection{{ end }})
+	if err != nil {
+		return false, err
+	}
+
+	return {{ .Builder.GetPackageName }}.ApplyResources(r, req, component{{ if .Builder.IsComponent }}, collection{{ end }})
+	{{- else }}
+	return true, nil
+	{{- end }}
+}
+
+// Teardown tears down the child resources of a component in reverse wave order, according to
+// their delete policies, when the component is deleted.  It is registered as a delete phase and
+// therefore has the signature of a phase.
+func (r *{{ .Resource.Kind }}Reconciler) Teardown(_ workload.Reconciler, req *workload.Request) (bool, error) {
+	{{- if .Builder.HasChildResources }}
+	{{- if .Builder.IsComponent }}
+	// the child resources cannot be created in memory without the collection, so they are left
+	// to garbage collection
+	if req.Collection == nil {
+		req.Log.Info("collection not found; skipping teardown of child resources")
+
+		return true, nil
+	}
+
+	{{ end -}}
+	component, {{ if .Builder.IsComponent }}collection,{{ end }} err := {{ .Builder.GetPackageName }}.ConvertWorkload(req.Workload{{ if .Builder.IsComponent }}, req.Collection{{ end }})
+	if err != nil {
+		return false, err
+	}
+
+	return {{ .Builder.GetPackageName }}.Teardown(req.Context, r, component{{ if .Builder.IsComponent }}, collection{{ end }})
+	{{- else }}
+	return true, nil
+	{{- end }}
+}
+
 // CheckReady will return whether a component is ready.
+{{- if .Builder.HasChildResources }}  The status fields of the component are
+// copied from its child resources beforehand, as the status is updated after each check.
+{{- end }}
 func (r *{{ .Resource.Kind }}Reconciler) CheckReady(req *workload.Request) (bool, error) {
+	{{- if .Builder.HasChildResources }}
+	if err := r.UpdateStatus(req); err != nil {
+		return false, err
+	}
+
+	{{ end -}}
 	return dependencies.{{ .Resource.Kind }}CheckReady(r, req)
 }
+{{- if .Builder.HasChildResources }}
+
+// UpdateStatus copies the status fields of a component from its child resources.
+func (r *{{ .Resource.Kind }}Reconciler) UpdateStatus(req *workload.Request) error {
+	component, {{ if .Builder.IsComponent }}collection,{{ end }} err := {{ .Builder.GetPackageName }}.ConvertWorkload(req.Workload{{ if .Builder.IsComponent }}, req.Collection{{ end }})
+	if err != nil {
+		return err
+	}
+
+	if err := {{ .Builder.GetPackageName }}.UpdateStatus(req.Context, r, component{{ if .Builder.IsComponent }}, collection{{ end }}); err != nil {
+		return fmt.Errorf("unable to update status fields, %w", err)
+	}
+
+	return nil
+}
+{{- end }}
 
 // Mutate will run the mutate function for the workload.
 func (r *{{ .Resource.Kind }}Reconciler) Mutate(
@@ -327,9 +528,58 @@ func (r *{{ .Resource.Kind }}Reconciler) Mutate(
 func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.InitializePhases()
 
-	baseController, err := ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicates.WorkloadPredicates()).
-		For(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(
+			&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{},
+			builder.WithPredicates(predicate.Or(predicates.WorkloadPredicates(), r.pausedChanged())),
+		)
+
+	// watch the child resources of the kinds built into Kubernetes by their owner references.
+	// Child resources of other kinds are watched once they have been created.
+	for _, child := range r.ownedKinds() {
+		controllerBuilder = controllerBuilder.
+			Owns(child, builder.WithPredicates(r.childChanged()))
+
+		r.SetWatch(child)
+	}
+	{{- if .LabeledKinds }}
+
+	// watch the child resources of cluster-scoped kinds by their labels, as they cannot have an
+	// owner reference to a component in a namespace.  A manager which only watches a set of
+	// namespaces may not read them, so they are only watched by a manager which watches all
+	// namespaces.
+	if scope.IsClusterWide() {
+		controllerBuilder = r.watchLabels(controllerBuilder, r.labeledKinds())
+	}
+	{{- end }}
+	{{- if .OtherNamespaceKinds }}
+
+	// watch the child resources of which the namespace is set by a field by their labels as well,
+	// as they cannot have an owner reference to the component when they are placed in another
+	// namespace.
+	controllerBuilder = r.watchLabels(controllerBuilder, r.otherNamespaceKinds())
+	{{- end }}
+
+	// the options of the controller are generated from the workload config, and may be overridden
+	// by the controller manager config and the flags of the manager
+	baseController, err := controllerBuilder.
+		WithOptions(config.ControllerOptions("{{ .Resource.Kind }}", config.Controller{
+			{{- with .Builder.GetControllerOptions }}
+			{{- if .MaxConcurrentReconciles }}
+			MaxConcurrentReconciles: {{ .MaxConcurrentReconciles }},
+			{{- end }}
+			{{- with .RateLimiter }}
+			RateLimiter: &config.RateLimiter{
+				BaseDelay: {{ .BaseDelayCode }},
+				MaxDelay:  {{ .MaxDelayCode }},
+				QPS:       {{ .QPSCode }},
+				Burst:     {{ .Burst }},
+			},
+			{{- end }}
+			{{- end }}
+		})).
+		//+operator-builder:user:begin:builder
+		//+operator-builder:user:end:builder
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("unable to setup controller, %w", err)
@@ -339,4 +589,170 @@ func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager) erro
 
 	return nil
 }
+
+// ownedKinds returns the kinds built into Kubernetes of the child resources of a component which
+// are watched by their owner references.
+func (r *{{ .Resource.Kind }}Reconciler) ownedKinds() []client.Object {
+	return r.kinds(
+		{{- range .OwnedKinds }}
+		schema.FromAPIVersionAndKind("{{ .Version }}", "{{ .Kind }}"),
+		{{- end }}
+	)
+}
+{{- if .LabeledKinds }}
+
+// labeledKinds returns the cluster-scoped kinds built into Kubernetes of the child resources of
+// a component which are watched by their labels.
+func (r *{{ .Resource.Kind }}Reconciler) labeledKinds() []client.Object {
+	return r.kinds(
+		{{- range .LabeledKinds }}
+		schema.FromAPIVersionAndKind("{{ .Version }}", "{{ .Kind }}"),
+		{{- end }}
+	)
+}
+{{- end }}
+{{- if .OtherNamespaceKinds }}
+
+// otherNamespaceKinds returns the namespaced kinds built into Kubernetes of the child resources
+// of a component which may be placed in another namespace than the component, and are watched by
+// their labels as well as their owner references.
+func (r *{{ .Resource.Kind }}Reconciler) otherNamespaceKinds() []client.Object {
+	return r.kinds(
+		{{- range .OtherNamespaceKinds }}
+		schema.FromAPIVersionAndKind("{{ .Version }}", "{{ .Kind }}"),
+		{{- end }}
+	)
+}
+{{- end }}
+{{- if .WatchesLabels }}
+
+// watchLabels watches the child resources of each kind by the labels which they are labeled with
+// when they cannot have an owner reference to the component.
+func (r *{{ .Resource.Kind }}Reconciler) watchLabels(controllerBuilder *builder.Builder, kinds []client.Object) *builder.Builder {
+	for _, child := range kinds {
+		controllerBuilder = controllerBuilder.
+			Watches(
+				&source.Kind{Type: child},
+				handler.EnqueueRequestsFromMapFunc(r.enqueueOwner),
+				builder.WithPredicates(r.childChanged()),
+			)
+
+		r.SetWatch(child)
+	}
+
+	return controllerBuilder
+}
+{{- end }}
+
+// kinds returns an object of each kind which is watched by the controller.
+func (r *{{ .Resource.Kind }}Reconciler) kinds(gvks ...schema.GroupVersionKind) []client.Object {
+	kinds := make([]client.Object, len(gvks))
+
+	for i, gvk := range gvks {
+		kind := &unstructured.Unstructured{}
+		kind.SetGroupVersionKind(gvk)
+
+		kinds[i] = kind
+	}
+
+	return kinds
+}
+
+{{ if .StatusKinds -}}
+// childChanged returns the filter which reconciles a component when one of its child resources
+// changes or is deleted.  The status of a child resource only reconciles the component when the
+// status or readiness of the component is read from it.  Child resources without a generation,
+// such as config maps, are reconciled on each update.
+{{ else -}}
+// childChanged returns the filter which reconciles a component when one of its child resources
+// changes, other than by its status, or is deleted.  Child resources without a generation, such
+// as config maps, are reconciled on each update.
+{{ end -}}
+func (r *{{ .Resource.Kind }}Reconciler) childChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectNew.GetGeneration() == 0 {
+				return true
+			}
+
+			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+				!reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) {
+				return true
+			}
+			{{- if .StatusKinds }}
+
+			switch e.ObjectNew.GetObjectKind().GroupVersionKind() {
+			case {{ range $i, $kind := .StatusKinds }}{{ if $i }},
+				{{ end }}schema.FromAPIVersionAndKind("{{ $kind.Version }}", "{{ $kind.Kind }}"){{ end }}:
+				return !reflect.DeepEqual(r.childStatus(e.ObjectOld), r.childStatus(e.ObjectNew))
+			}
+			{{- end }}
+
+			return false
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			// do not reconcile again when the controller has just created the child resource
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+{{- if .StatusKinds }}
+
+// childStatus returns the status of a child resource of a kind built into Kubernetes.
+func (r *{{ .Resource.Kind }}Reconciler) childStatus(object client.Object) interface{} {
+	child, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+
+	return child.Object["status"]
+}
+{{- end }}
+{{- if .WatchesLabels }}
+
+// enqueueOwner returns the request for the component which a child resource is labeled with.
+func (r *{{ .Resource.Kind }}Reconciler) enqueueOwner(object client.Object) []reconcile.Request {
+	labels := object.GetLabels()
+
+	name, ok := labels[{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}OwnerNameLabel]
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: name, Namespace: labels[{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}OwnerNamespaceLabel]}},
+	}
+}
+{{- end }}
+
+// pausedChanged returns the filter which reconciles a component when its paused annotation
+// changes, as the annotations of a component do not change its generation.
+func (r *{{ .Resource.Kind }}Reconciler) pausedChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			annotation := {{ .Resource.ImportAlias }}.{{ .Resource.Kind }}PausedAnnotation
+
+			return e.ObjectOld.GetAnnotations()[annotation] != e.ObjectNew.GetAnnotations()[annotation]
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// additional methods for the reconciler are preserved here.
+//+operator-builder:user:begin:methods
+//+operator-builder:user:end:methods
 `
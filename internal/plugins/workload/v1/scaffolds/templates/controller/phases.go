@@ -42,26 +42,27 @@ const phasesTemplate = `{{ .Boilerplate }}
 package {{ .Resource.Group }}
 
 import (
-	"time"
-
 	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
-	ctrl "sigs.k8s.io/controller-runtime"
+
+	"{{ .Repo }}/internal/cleanup"
 )
 
 // InitializePhases defines what phases should be run for each event loop. phases are executed
-// in the order they are listed.
+// in the order they are listed.  A phase which is not ready to proceed requeues the workload
+// according to its requeue policy, which is set by the workload config.  The delete phases run
+// while the finalizer of the workload is present, which is removed once each of them has
+// completed.
 func (r *{{ .Resource.Kind }}Reconciler) InitializePhases() {
 	// Create Phases
 	r.Phases.Register(
 		"Dependency",
 		phases.DependencyPhase,
 		phases.CreateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
 	)
 
 	r.Phases.Register(
 		"Create-Resources",
-		phases.CreateResourcesPhase,
+		r.ApplyResources,
 		phases.CreateEvent,
 	)
 
@@ -69,7 +70,6 @@ func (r *{{ .Resource.Kind }}Reconciler) InitializePhases() {
 		"Check-Ready",
 		phases.CheckReadyPhase,
 		phases.CreateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
 	)
 
 	r.Phases.Register(
@@ -83,12 +83,11 @@ func (r *{{ .Resource.Kind }}Reconciler) InitializePhases() {
 		"Dependency",
 		phases.DependencyPhase,
 		phases.UpdateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
 	)
 
 	r.Phases.Register(
 		"Create-Resources",
-		phases.CreateResourcesPhase,
+		r.ApplyResources,
 		phases.UpdateEvent,
 	)
 
@@ -96,7 +95,6 @@ func (r *{{ .Resource.Kind }}Reconciler) InitializePhases() {
 		"Check-Ready",
 		phases.CheckReadyPhase,
 		phases.UpdateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
 	)
 
 	r.Phases.Register(
@@ -106,6 +104,18 @@ func (r *{{ .Resource.Kind }}Reconciler) InitializePhases() {
 	)
 
 	// Delete Phases
+	r.Phases.Register(
+		"Cleanup",
+		cleanup.{{ .Resource.Kind }}Cleanup,
+		phases.DeleteEvent,
+	)
+
+	r.Phases.Register(
+		"Teardown",
+		r.Teardown,
+		phases.DeleteEvent,
+	)
+
 	r.Phases.Register(
 		"DeletionComplete",
 		phases.DeletionCompletePhase,
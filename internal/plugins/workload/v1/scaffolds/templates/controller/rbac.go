@@ -0,0 +1,68 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package controller
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ machinery.Template = &RBAC{}
+
+// RBAC scaffolds the RBAC markers for the workload's controller.  The markers are kept
+// separate from the controller so that they may be regenerated as the child resources
+// of a workload change without overwriting the controller.
+type RBAC struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
+}
+
+func (f *RBAC) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"controllers",
+		f.Resource.Group,
+		fmt.Sprintf("%s_rbac.go", utils.ToFileName(f.Resource.Kind)),
+	)
+
+	f.TemplateBody = rbacTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint: lll
+const rbacTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Group }}
+
+// NOTE: this file is regenerated from the workload config and manifests.  Do not edit.
+
+// +kubebuilder:rbac:groups={{ .Resource.Group }}.{{ .Resource.Domain }},resources={{ .Resource.Plural }},verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups={{ .Resource.Group }}.{{ .Resource.Domain }},resources={{ .Resource.Plural }}/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups={{ .Resource.Group }}.{{ .Resource.Domain }},resources={{ .Resource.Plural }}/finalizers,verbs=update
+{{ range .Builder.GetRBACRules -}}
+// +kubebuilder:rbac:groups={{ .Group }},resources={{ .Resource }},verbs={{ .VerbString }}
+{{ end }}
+
+// Until Webhooks are implemented we need to list and watch namespaces to ensure
+// they are available before deploying resources,
+// See:
+//   - https://github.com/vmware-tanzu-labs/operator-builder/issues/141
+//   - https://github.com/vmware-tanzu-labs/operator-builder/issues/162
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=list;watch
+
+// The controller records events, e.g. when drift is detected or reconciliation is paused.
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+`
@@ -27,8 +27,14 @@ func goModDependencyMap() map[string]string {
 		"github.com/nukleros/operator-builder-tools": "v0.2.0",
 		"github.com/onsi/ginkgo":                     "v1.16.4",
 		"github.com/onsi/gomega":                     "v1.15.0",
+		"github.com/prometheus/client_golang":        "v1.11.0",
 		"github.com/spf13/cobra":                     "v1.2.1",
 		"github.com/stretchr/testify":                "v1.7.0",
+		"go.opentelemetry.io/otel":                   "v0.20.0",
+		"go.opentelemetry.io/otel/exporters/otlp":    "v0.20.0",
+		"go.opentelemetry.io/otel/exporters/stdout":  "v0.20.0",
+		"go.opentelemetry.io/otel/sdk":               "v0.20.0",
+		"go.opentelemetry.io/otel/trace":             "v0.20.0",
 		"gopkg.in/yaml.v2":                           "v2.4.0",
 		"k8s.io/api":                                 "v0.22.2",
 		"k8s.io/apimachinery":                        "v0.22.2",
@@ -0,0 +1,54 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cleanup
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+)
+
+var _ machinery.Template = &Component{}
+
+// Component scaffolds the workload's cleanup function which is called when the workload is
+// deleted.
+type Component struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+}
+
+func (f *Component) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"cleanup",
+		fmt.Sprintf("%s.go", utils.ToFileName(f.Resource.Kind)),
+	)
+
+	f.TemplateBody = componentTemplate
+
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const componentTemplate = `{{ .Boilerplate }}
+
+package cleanup
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+)
+
+// {{ .Resource.Kind }}Cleanup performs the logic to clean up resources outside of the cluster, such as
+// cloud resources, when a {{ .Resource.Kind }} object is deleted.  It is called before the child resources
+// are deleted and is called again until it returns true.
+func {{ .Resource.Kind }}Cleanup(r workload.Reconciler, req *workload.Request) (bool, error) {
+	return true, nil
+}
+`
@@ -0,0 +1,318 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Config{}
+
+// Config scaffolds the loading of the controller manager config from the config file of the
+// manager, from which the controllers read their settings.
+type Config struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+}
+
+func (f *Config) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"config",
+		"config.go",
+	)
+
+	f.TemplateBody = configTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const configTemplate = `{{ .Boilerplate }}
+
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+
+	configv1alpha1 "{{ .Repo }}/apis/config/v1alpha1"
+	"{{ .Repo }}/internal/scope"
+)
+
+// NOTE: this file is overwritten when an api is created or updated.
+
+var (
+	ErrInvalidConfig     = errors.New("invalid controller manager config")
+	ErrInvalidController = errors.New("invalid controller options")
+)
+
+// loaded is the controller manager config which was loaded from the config file.  It is empty
+// when the manager was started without a config file.
+var loaded = &configv1alpha1.ControllerManagerConfig{}
+
+// defaultRateLimiter is the default rate limiter of controller-runtime.  When the workload
+// config sets no rate limiter, the settings of the rate-limiter flag are applied to it.
+var defaultRateLimiter = RateLimiter{
+	BaseDelay: 5 * time.Millisecond,
+	MaxDelay:  1000 * time.Second,
+	QPS:       10,
+	Burst:     100,
+}
+
+// Controller are the options of the controller of a workload which were generated from the
+// workload config.
+type Controller struct {
+	// MaxConcurrentReconciles is the number of workloads which are reconciled at the same time.
+	// The default of controller-runtime is used when it is zero.
+	MaxConcurrentReconciles int
+
+	// RateLimiter is the rate limiter of the queue of the controller.  The default rate limiter
+	// of controller-runtime is used when it is nil.
+	RateLimiter *RateLimiter
+}
+
+// RateLimiter is the rate limiter of the queue of a controller, which delays a failing workload
+// exponentially from the base delay up to the max delay, and limits all workloads to the queries
+// per second with bursts of up to the burst.
+type RateLimiter struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	QPS       float64
+	Burst     int
+}
+
+func (limiter *RateLimiter) build() ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(limiter.BaseDelay, limiter.MaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(limiter.QPS), limiter.Burst)},
+	)
+}
+
+// concurrencyOverrides are the max concurrent reconciles set by the max-concurrent-reconciles
+// flag for every kind, by the empty kind, or by kind.
+var concurrencyOverrides = map[string]int{}
+
+// rateLimiterOverrides are the settings of the rate limiters set by the rate-limiter flag for
+// every kind, by the empty kind, or by kind.
+var rateLimiterOverrides = map[string][]string{}
+
+// BindFlags binds the flags which override the options of the controllers to a flag set.
+func BindFlags(fs *flag.FlagSet) {
+	fs.Var(&concurrencyFlag{}, "max-concurrent-reconciles",
+		"Override the number of workloads which a controller reconciles at the same time, e.g. <Kind>:4. "+
+			"The kind may be omitted to override the controller of every kind.  May be repeated.")
+	fs.Var(&rateLimiterFlag{}, "rate-limiter",
+		"Override the rate limiter of a controller, e.g. <Kind>:baseDelay=5ms,maxDelay=5m,qps=10,burst=100. "+
+			"The kind may be omitted to override the controller of every kind.  May be repeated.")
+}
+
+// splitKind splits a flag value in the format [Kind:]value into its kind and value.
+func splitKind(value string) (string, string) {
+	if i := strings.Index(value, ":"); i >= 0 {
+		return value[:i], value[i+1:]
+	}
+
+	return "", value
+}
+
+// concurrencyFlag is the flag which overrides the max concurrent reconciles of a controller.
+type concurrencyFlag struct {
+	values []string
+}
+
+func (f *concurrencyFlag) String() string {
+	return strings.Join(f.values, " ")
+}
+
+// Set parses the max concurrent reconciles in the format [Kind:]4.
+func (f *concurrencyFlag) Set(value string) error {
+	kind, count := splitKind(value)
+
+	concurrency, err := strconv.Atoi(count)
+	if err != nil || concurrency < 1 {
+		return fmt.Errorf("%w; max concurrent reconciles must be a positive number in %s", ErrInvalidController, value)
+	}
+
+	concurrencyOverrides[kind] = concurrency
+	f.values = append(f.values, value)
+
+	return nil
+}
+
+// rateLimiterFlag is the flag which overrides the rate limiter of a controller.
+type rateLimiterFlag struct {
+	values []string
+}
+
+func (f *rateLimiterFlag) String() string {
+	return strings.Join(f.values, " ")
+}
+
+// Set parses a rate limiter in the format [Kind:]baseDelay=5ms,maxDelay=5m,qps=10,burst=100.
+func (f *rateLimiterFlag) Set(value string) error {
+	kind, settings := splitKind(value)
+
+	// check the settings against a rate limiter, so that an invalid flag fails on startup
+	limiter := defaultRateLimiter
+
+	if err := limiter.apply(strings.Split(settings, ",")); err != nil {
+		return fmt.Errorf("%w; %s", err, value)
+	}
+
+	rateLimiterOverrides[kind] = append(rateLimiterOverrides[kind], strings.Split(settings, ",")...)
+	f.values = append(f.values, value)
+
+	return nil
+}
+
+// apply overrides the values of a rate limiter with settings in the format baseDelay=5ms.
+func (limiter *RateLimiter) apply(settings []string) error {
+	for _, setting := range settings {
+		name, val := setting, ""
+		if i := strings.Index(setting, "="); i >= 0 {
+			name, val = setting[:i], setting[i+1:]
+		}
+
+		switch name {
+		case "baseDelay", "maxDelay":
+			duration, err := time.ParseDuration(val)
+			if err != nil || duration <= 0 {
+				return fmt.Errorf("%w; %s must be a positive duration", ErrInvalidController, name)
+			}
+
+			if name == "baseDelay" {
+				limiter.BaseDelay = duration
+			} else {
+				limiter.MaxDelay = duration
+			}
+		case "qps":
+			qps, err := strconv.ParseFloat(val, 64)
+			if err != nil || qps <= 0 {
+				return fmt.Errorf("%w; %s must be a positive number", ErrInvalidController, name)
+			}
+
+			limiter.QPS = qps
+		case "burst":
+			burst, err := strconv.Atoi(val)
+			if err != nil || burst <= 0 {
+				return fmt.Errorf("%w; %s must be a positive number", ErrInvalidController, name)
+			}
+
+			limiter.Burst = burst
+		default:
+			return fmt.Errorf("%w; unknown rate limiter setting %s", ErrInvalidController, name)
+		}
+	}
+
+	return nil
+}
+
+// ControllerOptions returns the options of the controller of a kind, after applying the settings
+// of the controller manager config and the overrides of the flags for every kind and for the kind,
+// in that order, to the options which were generated from the workload config.
+func ControllerOptions(kind string, generated Controller) controller.Options {
+	options := generated
+
+	if concurrency := Workload(kind).MaxConcurrentReconciles; concurrency > 0 {
+		options.MaxConcurrentReconciles = concurrency
+	}
+
+	for _, key := range []string{"", kind} {
+		if concurrency, found := concurrencyOverrides[key]; found {
+			options.MaxConcurrentReconciles = concurrency
+		}
+
+		if settings, found := rateLimiterOverrides[key]; found {
+			limiter := defaultRateLimiter
+			if options.RateLimiter != nil {
+				limiter = *options.RateLimiter
+			}
+
+			// the settings were checked when the flag was parsed
+			_ = limiter.apply(settings)
+
+			options.RateLimiter = &limiter
+		}
+	}
+
+	controllerOptions := controller.Options{MaxConcurrentReconciles: options.MaxConcurrentReconciles}
+
+	if options.RateLimiter != nil {
+		controllerOptions.RateLimiter = options.RateLimiter.build()
+	}
+
+	return controllerOptions
+}
+
+// Load loads the controller manager config from a file and sets the options of the manager,
+// and the namespaces which are watched by the manager, from it.  The options which are already
+// set and the watch-namespaces flag take precedence over the config file.
+func Load(path string, options *ctrl.Options) error {
+	if err := configv1alpha1.AddToScheme(options.Scheme); err != nil {
+		return fmt.Errorf("unable to add config to scheme, %w", err)
+	}
+
+	config := &configv1alpha1.ControllerManagerConfig{}
+
+	loadedOptions, err := options.AndFrom(ctrl.ConfigFile().AtPath(path).OfKind(config))
+	if err != nil {
+		return fmt.Errorf("unable to load config file %s, %w", path, err)
+	}
+
+	if err := validate(config); err != nil {
+		return fmt.Errorf("%w; %s", err, path)
+	}
+
+	*options = loadedOptions
+	loaded = config
+
+	scope.Default(config.Namespaces)
+
+	return nil
+}
+
+// Workload returns the settings of the controller of a workload by its kind.
+func Workload(kind string) configv1alpha1.WorkloadConfig {
+	return loaded.Workloads[kind]
+}
+
+// validate checks that the settings of the controllers are valid.
+func validate(config *configv1alpha1.ControllerManagerConfig) error {
+	for kind, workload := range config.Workloads {
+		if workload.MaxConcurrentReconciles < 0 {
+			return fmt.Errorf("%w; maxConcurrentReconciles of %s must not be negative", ErrInvalidConfig, kind)
+		}
+
+		for phase, policy := range workload.Requeue {
+			if policy.Interval != nil && policy.Interval.Duration <= 0 {
+				return fmt.Errorf("%w; interval of phase %s of %s must be positive", ErrInvalidConfig, phase, kind)
+			}
+
+			if policy.MaxInterval != nil && policy.MaxInterval.Duration < 0 {
+				return fmt.Errorf("%w; maxInterval of phase %s of %s must not be negative", ErrInvalidConfig, phase, kind)
+			}
+
+			if policy.MaxRetries != nil && *policy.MaxRetries < 0 {
+				return fmt.Errorf("%w; maxRetries of phase %s of %s must not be negative", ErrInvalidConfig, phase, kind)
+			}
+		}
+	}
+
+	return nil
+}
+`
@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 
 	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
 )
 
 var _ machinery.Template = &Component{}
@@ -21,6 +22,9 @@ type Component struct {
 	machinery.BoilerplateMixin
 	machinery.RepositoryMixin
 	machinery.ResourceMixin
+
+	// input fields
+	Builder workloadv1.WorkloadAPIBuilder
 }
 
 func (f *Component) SetTemplateDefaults() error {
@@ -43,10 +47,18 @@ package dependencies
 
 import (
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"{{ .Resource.Path }}/{{ .Builder.GetPackageName }}"
 )
 
-// {{ .Resource.Kind }}CheckReady performs the logic to determine if a {{ .Resource.Kind }} object is ready.
+// {{ .Resource.Kind }}CheckReady performs the logic to determine if a {{ .Resource.Kind }} object is ready.  By
+// default, a {{ .Resource.Kind }} is ready once each of its child resources is ready.
 func {{ .Resource.Kind }}CheckReady(r workload.Reconciler, req *workload.Request) (bool, error) {
-	return true, nil
+	component, {{ if .Builder.IsComponent }}collection,{{ end }} err := {{ .Builder.GetPackageName }}.ConvertWorkload(req.Workload{{ if .Builder.IsComponent }}, req.Collection{{ end }})
+	if err != nil {
+		return false, err
+	}
+
+	return {{ .Builder.GetPackageName }}.CheckReady(req.Context, r, component{{ if .Builder.IsComponent }}, collection{{ end }})
 }
 `
@@ -0,0 +1,191 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Metrics{}
+
+// Metrics scaffolds the prometheus metrics which are recorded by the controllers as they
+// execute their phases and apply child resources.
+type Metrics struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+func (f *Metrics) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"metrics",
+		"metrics.go",
+	)
+
+	f.TemplateBody = metricsTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const metricsTemplate = `{{ .Boilerplate }}
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// NOTE: this file is overwritten when an api is created or updated.
+
+// outcomes of applying a child resource.
+const (
+	ApplyResultApplied = "applied"
+	ApplyResultPending = "pending"
+	ApplyResultFailed  = "failed"
+)
+
+var workloadLabels = []string{"kind", "namespace", "name"}
+
+var (
+	phaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "workload_phase_duration_seconds",
+			Help: "Duration of the execution of a phase for a workload.",
+		},
+		append(workloadLabels, "phase"),
+	)
+
+	phaseFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "workload_phase_failures_total",
+			Help: "Total number of executions of a phase for a workload which returned an error.",
+		},
+		append(workloadLabels, "phase"),
+	)
+
+	phaseRequeues = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "workload_phase_requeues_total",
+			Help: "Total number of executions of a phase for a workload which requeued the workload.",
+		},
+		append(workloadLabels, "phase"),
+	)
+
+	childApplies = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "workload_child_apply_total",
+			Help: "Total number of applies of a child resource of a workload by their result.",
+		},
+		append(workloadLabels, "child_kind", "child_name", "result"),
+	)
+
+	workloadReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "workload_ready",
+			Help: "Whether a workload is ready (1) or not ready (0).",
+		},
+		workloadLabels,
+	)
+)
+
+func init() {
+	// register with the registry which is served by the manager at its metrics endpoint
+	metrics.Registry.MustRegister(
+		phaseDuration,
+		phaseFailures,
+		phaseRequeues,
+		childApplies,
+		workloadReady,
+	)
+}
+
+// Registry is a registry of phases which records the duration of each phase that is executed,
+// and whether it failed or requeued the workload.
+type Registry struct {
+	phases.Registry
+}
+
+// Register adds a phase to the registry for the provided event loop.
+func (registry *Registry) Register(
+	name string,
+	definition phases.HandlerFunc,
+	event phases.LifecycleEvent,
+	options ...phases.PhaseOption,
+) {
+	registry.Registry.Register(name, instrument(name, definition), event, options...)
+}
+
+// instrument returns the definition of a phase which records its metrics.  Optimistic lock
+// errors are not recorded as failures, as the phase is retried with the latest workload.
+func instrument(name string, definition phases.HandlerFunc) phases.HandlerFunc {
+	return func(r workload.Reconciler, req *workload.Request) (bool, error) {
+		start := time.Now()
+
+		proceed, err := definition(r, req)
+
+		labels := labelValues(req.Workload, name)
+
+		phaseDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		switch {
+		case err != nil && !phases.IsOptimisticLockError(err):
+			phaseFailures.WithLabelValues(labels...).Inc()
+		case err == nil && !proceed:
+			phaseRequeues.WithLabelValues(labels...).Inc()
+		}
+
+		return proceed, err
+	}
+}
+
+// RecordApply records the result of applying a child resource of a workload.
+func RecordApply(parent workload.Workload, child client.Object, applied bool, err error) {
+	result := ApplyResultApplied
+
+	switch {
+	case err != nil:
+		result = ApplyResultFailed
+	case !applied:
+		result = ApplyResultPending
+	}
+
+	childApplies.WithLabelValues(
+		labelValues(parent, child.GetObjectKind().GroupVersionKind().Kind, child.GetName(), result)...,
+	).Inc()
+}
+
+// SetReady records whether a workload is ready.
+func SetReady(parent workload.Workload) {
+	ready := 0.0
+	if parent.GetReadyStatus() {
+		ready = 1.0
+	}
+
+	workloadReady.WithLabelValues(labelValues(parent)...).Set(ready)
+}
+
+// Forget removes the ready metric of a workload which no longer exists.  The counters and
+// histograms of the workload are kept, as they are cumulative.
+func Forget(kind, namespace, name string) {
+	workloadReady.DeleteLabelValues(kind, namespace, name)
+}
+
+// labelValues returns the values of the workload labels of a workload followed by the values
+// of any additional labels.
+func labelValues(parent workload.Workload, values ...string) []string {
+	return append(
+		[]string{parent.GetWorkloadGVK().Kind, parent.GetNamespace(), parent.GetName()},
+		values...,
+	)
+}
+`
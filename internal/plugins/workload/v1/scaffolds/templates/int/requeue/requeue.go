@@ -0,0 +1,326 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package requeue
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Requeue{}
+
+// Requeue scaffolds the requeue policies of the phases, which set the interval, backoff and
+// retries with which a workload is requeued by a phase that is not ready to proceed.
+type Requeue struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+}
+
+func (f *Requeue) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"requeue",
+		"requeue.go",
+	)
+
+	f.TemplateBody = requeueTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const requeueTemplate = `{{ .Boilerplate }}
+
+package requeue
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	configv1alpha1 "{{ .Repo }}/apis/config/v1alpha1"
+	"{{ .Repo }}/internal/config"
+	"{{ .Repo }}/internal/tracing"
+)
+
+// NOTE: this file is overwritten when an api is created or updated.
+
+var ErrInvalidPolicy = errors.New("invalid requeue policy")
+
+// Policy sets how a workload is requeued by a phase which is not ready to proceed.
+type Policy struct {
+	// Interval is the time after which the workload is requeued.
+	Interval time.Duration
+
+	// MaxInterval caps the interval, which is doubled on each consecutive requeue by the
+	// phase, when it is greater than the interval.
+	MaxInterval time.Duration
+
+	// MaxRetries is the number of consecutive requeues by the phase after which the workload is
+	// no longer requeued, and is only reconciled again when it or one of its child resources
+	// changes.  The workload is requeued until the phase proceeds when it is zero.
+	MaxRetries int
+}
+
+// after returns the time after which a workload is requeued on a consecutive attempt.
+func (policy Policy) after(attempt int) time.Duration {
+	interval := policy.Interval
+
+	for i := 1; i < attempt && interval < policy.MaxInterval; i++ {
+		interval *= 2
+	}
+
+	if policy.MaxInterval > policy.Interval && interval > policy.MaxInterval {
+		return policy.MaxInterval
+	}
+
+	return interval
+}
+
+// override is a policy set by the requeue-policy flag, of which only the values that are set
+// override the policy of a phase.
+type override struct {
+	interval    *time.Duration
+	maxInterval *time.Duration
+	maxRetries  *int
+}
+
+func (o override) apply(policy Policy) Policy {
+	if o.interval != nil {
+		policy.Interval = *o.interval
+	}
+
+	if o.maxInterval != nil {
+		policy.MaxInterval = *o.maxInterval
+	}
+
+	if o.maxRetries != nil {
+		policy.MaxRetries = *o.maxRetries
+	}
+
+	return policy
+}
+
+// configOverride returns the override of a policy which is set in the controller manager config.
+func configOverride(policy configv1alpha1.RequeuePolicy) override {
+	o := override{maxRetries: policy.MaxRetries}
+
+	if policy.Interval != nil {
+		o.interval = &policy.Interval.Duration
+	}
+
+	if policy.MaxInterval != nil {
+		o.maxInterval = &policy.MaxInterval.Duration
+	}
+
+	return o
+}
+
+// overrides are the policies set by the requeue-policy flag by phase, or by kind and phase.
+var overrides = map[string]override{}
+
+// BindFlags binds the flag which overrides the requeue policies of the phases to a flag set.
+func BindFlags(fs *flag.FlagSet) {
+	fs.Var(&policyFlag{}, "requeue-policy",
+		"Override the requeue policy of a phase, e.g. <Kind>.Check-Ready:interval=10s,maxInterval=5m,maxRetries=20. "+
+			"The kind may be omitted to override the phase of every kind.  May be repeated.")
+}
+
+// policyFlag is the flag which overrides the requeue policy of a phase.
+type policyFlag struct {
+	values []string
+}
+
+func (f *policyFlag) String() string {
+	return strings.Join(f.values, " ")
+}
+
+// Set parses a policy in the format [Kind.]Phase:interval=10s,maxInterval=5m,maxRetries=20.
+func (f *policyFlag) Set(value string) error {
+	key, settings := value, ""
+	if i := strings.Index(value, ":"); i >= 0 {
+		key, settings = value[:i], value[i+1:]
+	}
+
+	if key == "" || settings == "" {
+		return fmt.Errorf("%w; %s", ErrInvalidPolicy, value)
+	}
+
+	o := overrides[key]
+
+	for _, setting := range strings.Split(settings, ",") {
+		name, val := setting, ""
+		if i := strings.Index(setting, "="); i >= 0 {
+			name, val = setting[:i], setting[i+1:]
+		}
+
+		switch name {
+		case "interval", "maxInterval":
+			duration, err := time.ParseDuration(val)
+			if err != nil || duration < 0 {
+				return fmt.Errorf("%w; %s must be a duration in %s", ErrInvalidPolicy, name, value)
+			}
+
+			if name == "interval" {
+				o.interval = &duration
+			} else {
+				o.maxInterval = &duration
+			}
+		case "maxRetries":
+			retries, err := strconv.Atoi(val)
+			if err != nil || retries < 0 {
+				return fmt.Errorf("%w; %s must be a number in %s", ErrInvalidPolicy, name, value)
+			}
+
+			o.maxRetries = &retries
+		default:
+			return fmt.Errorf("%w; unknown setting %s in %s", ErrInvalidPolicy, name, value)
+		}
+	}
+
+	overrides[key] = o
+	f.values = append(f.values, value)
+
+	return nil
+}
+
+// Registry is a registry of phases which requeues a workload according to the policy of the
+// phase that is not ready to proceed, in addition to tracing the phases and recording their
+// metrics.
+type Registry struct {
+	tracing.Registry
+
+	// Policies are the requeue policies of the phases by their name.  A phase without a policy
+	// requeues the workload with the result of the phase.
+	Policies map[string]Policy
+
+	mutex    sync.Mutex
+	attempts map[string]int
+	results  map[*workload.Request]ctrl.Result
+}
+
+// Register adds a phase to the registry for the provided event loop.
+func (registry *Registry) Register(
+	name string,
+	definition phases.HandlerFunc,
+	event phases.LifecycleEvent,
+	options ...phases.PhaseOption,
+) {
+	registry.Registry.Register(name, registry.instrument(name, definition), event, options...)
+}
+
+// HandleExecution executes the phases for the lifecycle event of a workload and returns the
+// result of the requeue policy of the phase which was not ready to proceed, if any.
+func (registry *Registry) HandleExecution(r workload.Reconciler, req *workload.Request) (ctrl.Result, error) {
+	result, err := registry.Registry.HandleExecution(r, req)
+
+	registry.mutex.Lock()
+	requeue, ok := registry.results[req]
+	delete(registry.results, req)
+	registry.mutex.Unlock()
+
+	if ok && err == nil {
+		return requeue, nil
+	}
+
+	return result, err
+}
+
+// Forget removes the consecutive requeues of a workload which no longer exists.
+func (registry *Registry) Forget(namespace, name string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	prefix := attemptKey(namespace, name, "")
+
+	for key := range registry.attempts {
+		if strings.HasPrefix(key, prefix) {
+			delete(registry.attempts, key)
+		}
+	}
+}
+
+// Policy returns the requeue policy of a phase of a kind, after applying the policy of the
+// controller manager config and the overrides of the requeue-policy flag for the phase and for
+// the kind and phase, in that order.
+func (registry *Registry) Policy(kind, phase string) (Policy, bool) {
+	policy, ok := registry.Policies[phase]
+
+	if configured, found := config.Workload(kind).Requeue[phase]; found {
+		policy, ok = configOverride(configured).apply(policy), true
+	}
+
+	for _, key := range []string{phase, kind + "." + phase} {
+		if o, found := overrides[key]; found {
+			policy, ok = o.apply(policy), true
+		}
+	}
+
+	return policy, ok && policy.Interval > 0
+}
+
+// instrument returns the definition of a phase which counts the consecutive requeues of a
+// workload by the phase and stores the result of its requeue policy for the request.
+func (registry *Registry) instrument(name string, definition phases.HandlerFunc) phases.HandlerFunc {
+	return func(r workload.Reconciler, req *workload.Request) (bool, error) {
+		proceed, err := definition(r, req)
+		if err != nil {
+			return proceed, err
+		}
+
+		policy, ok := registry.Policy(req.Workload.GetWorkloadGVK().Kind, name)
+
+		key := attemptKey(req.Workload.GetNamespace(), req.Workload.GetName(), name)
+
+		registry.mutex.Lock()
+		defer registry.mutex.Unlock()
+
+		if registry.attempts == nil {
+			registry.attempts = map[string]int{}
+			registry.results = map[*workload.Request]ctrl.Result{}
+		}
+
+		if proceed || !ok {
+			delete(registry.attempts, key)
+
+			return proceed, nil
+		}
+
+		registry.attempts[key]++
+		attempt := registry.attempts[key]
+
+		if policy.MaxRetries > 0 && attempt > policy.MaxRetries {
+			registry.results[req] = ctrl.Result{}
+
+			if attempt == policy.MaxRetries+1 {
+				message := fmt.Sprintf("phase %s is not ready after %d retries; no longer requeueing", name, policy.MaxRetries)
+
+				req.Log.Info(message)
+				r.GetEventRecorder().Event(req.Workload, corev1.EventTypeWarning, "RequeueLimitReached", message)
+			}
+
+			return proceed, nil
+		}
+
+		registry.results[req] = ctrl.Result{RequeueAfter: policy.after(attempt)}
+
+		return proceed, nil
+	}
+}
+
+func attemptKey(namespace, name, phase string) string {
+	return namespace + "/" + name + "/" + phase
+}
+`
@@ -0,0 +1,151 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scope
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Scope{}
+
+// Scope scaffolds the namespaces which are watched by the manager, which restrict the cache of
+// the manager to a single namespace or a set of namespaces instead of the whole cluster.
+type Scope struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+func (f *Scope) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"scope",
+		"scope.go",
+	)
+
+	f.TemplateBody = scopeTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const scopeTemplate = `{{ .Boilerplate }}
+
+package scope
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// NOTE: this file is overwritten when an api is created or updated.
+
+// WatchNamespaceEnv is the environment variable which sets the default of the watch-namespaces
+// flag, e.g. from the namespace of the pod of the manager.
+const WatchNamespaceEnv = "WATCH_NAMESPACE"
+
+// namespaces are the namespaces which are watched by the manager.  The manager watches all
+// namespaces when there are none.
+var namespaces []string
+
+// namespacesSet is whether the namespaces were set by the watch-namespaces flag or the
+// WATCH_NAMESPACE environment variable, which take precedence over the config file.
+var namespacesSet bool
+
+// BindFlags binds the flag which sets the namespaces that are watched by the manager to a
+// flag set.
+func BindFlags(fs *flag.FlagSet) {
+	flagValue := &namespacesFlag{}
+
+	if value := os.Getenv(WatchNamespaceEnv); value != "" {
+		_ = flagValue.Set(value)
+	}
+
+	fs.Var(flagValue, "watch-namespaces",
+		"A comma separated list of the namespaces which are watched by the manager, which watches all namespaces "+
+			"when it is empty.  Defaults to the "+WatchNamespaceEnv+" environment variable.")
+}
+
+// namespacesFlag is the flag which sets the namespaces that are watched by the manager.
+type namespacesFlag struct{}
+
+func (f *namespacesFlag) String() string {
+	return strings.Join(namespaces, ",")
+}
+
+// Set parses a comma separated list of namespaces, e.g. tenant-a,tenant-b.
+func (f *namespacesFlag) Set(value string) error {
+	namespaces, namespacesSet = []string{}, true
+
+	for _, namespace := range strings.Split(value, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" && !contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	return nil
+}
+
+// Default sets the namespaces which are watched by the manager, e.g. from the config file,
+// unless they were set by the watch-namespaces flag or the WATCH_NAMESPACE environment variable.
+func Default(values []string) {
+	if namespacesSet {
+		return
+	}
+
+	namespaces = []string{}
+
+	for _, namespace := range values {
+		if !contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+}
+
+// Namespaces returns the namespaces which are watched by the manager, or none when the manager
+// watches all namespaces.
+func Namespaces() []string {
+	return namespaces
+}
+
+// IsClusterWide returns whether the manager watches all namespaces.
+func IsClusterWide() bool {
+	return len(namespaces) == 0
+}
+
+// Watches returns whether the manager watches a namespace.
+func Watches(namespace string) bool {
+	return IsClusterWide() || contains(namespaces, namespace)
+}
+
+func contains(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetCache restricts the cache of the manager to the namespaces which it watches.  A single
+// namespace is watched by the cache of the manager, and a set of namespaces by a cache for
+// each namespace.
+func SetCache(options *ctrl.Options) {
+	switch len(namespaces) {
+	case 0:
+		return
+	case 1:
+		options.Namespace = namespaces[0]
+	default:
+		options.Namespace = ""
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+}
+`
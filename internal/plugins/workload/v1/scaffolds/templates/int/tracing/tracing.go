@@ -0,0 +1,210 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package tracing
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Tracing{}
+
+// Tracing scaffolds the setup of the opentelemetry exporter and the spans which are opened by
+// the controllers as they reconcile, execute their phases and apply child resources.
+type Tracing struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.ProjectNameMixin
+	machinery.RepositoryMixin
+}
+
+func (f *Tracing) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"tracing",
+		"tracing.go",
+	)
+
+	f.TemplateBody = tracingTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const tracingTemplate = `{{ .Boilerplate }}
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"{{ .Repo }}/internal/metrics"
+)
+
+// NOTE: this file is overwritten when an api is created or updated.
+
+// exporters of the spans.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+const (
+	serviceName     = "{{ .ProjectName }}"
+	instrumentation = "{{ .Repo }}"
+)
+
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// Options are the options of the exporter of the spans.
+type Options struct {
+	// Exporter is the exporter of the spans, which is one of none, otlp or stdout.
+	Exporter string
+
+	// Endpoint is the address of the OTLP collector which receives the spans over gRPC.
+	Endpoint string
+
+	// Insecure disables the transport security of the connection to the OTLP collector.
+	Insecure bool
+}
+
+// Setup sets the global tracer provider to one which exports the spans with the exporter of
+// the options and returns the function which flushes and stops the exporter.  The spans are
+// not recorded when the exporter is none.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+
+	var err error
+
+	switch options.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		driverOptions := []otlpgrpc.Option{otlpgrpc.WithEndpoint(options.Endpoint)}
+		if options.Insecure {
+			driverOptions = append(driverOptions, otlpgrpc.WithInsecure())
+		}
+
+		exporter, err = otlp.NewExporter(ctx, otlpgrpc.NewDriver(driverOptions...))
+	case ExporterStdout:
+		exporter, err = stdout.NewExporter(stdout.WithWriter(os.Stdout), stdout.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("%w; %s", ErrUnknownExporter, options.Exporter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s tracing exporter, %w", options.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(serviceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// StartReconcile starts the span of the reconciliation of a workload.  The returned context is
+// stored as the context of the workload request so that the spans of the phases and child
+// resources are children of the span.
+func StartReconcile(ctx context.Context, kind string, request ctrl.Request) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, "Reconcile "+kind,
+		trace.WithAttributes(
+			attribute.String("workload.kind", kind),
+			attribute.String("workload.namespace", request.Namespace),
+			attribute.String("workload.name", request.Name),
+		),
+	)
+}
+
+// Start starts a span as a child of the span in the context of a workload request.  The context
+// of the request is replaced with the context of the new span until the returned function ends
+// the span with the error, if any, of the traced operation.
+func Start(req *workload.Request, name string, attributes ...attribute.KeyValue) func(error) {
+	parent := req.Context
+
+	ctx, span := otel.Tracer(instrumentation).Start(parent, name, trace.WithAttributes(attributes...))
+
+	req.Context = ctx
+
+	return func(err error) {
+		End(span, err)
+
+		req.Context = parent
+	}
+}
+
+// End ends a span and records the error, if any, of the traced operation.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Registry is a registry of phases which opens a span for each phase that is executed, in
+// addition to recording the metrics of the phase.
+type Registry struct {
+	metrics.Registry
+}
+
+// Register adds a phase to the registry for the provided event loop.
+func (registry *Registry) Register(
+	name string,
+	definition phases.HandlerFunc,
+	event phases.LifecycleEvent,
+	options ...phases.PhaseOption,
+) {
+	registry.Registry.Register(name, instrument(name, definition), event, options...)
+}
+
+// instrument returns the definition of a phase which is traced with a span.
+func instrument(name string, definition phases.HandlerFunc) phases.HandlerFunc {
+	return func(r workload.Reconciler, req *workload.Request) (bool, error) {
+		end := Start(req, "Phase "+name, attribute.String("phase", name))
+
+		proceed, err := definition(r, req)
+
+		trace.SpanFromContext(req.Context).SetAttributes(attribute.Bool("phase.proceed", proceed))
+		end(err)
+
+		return proceed, err
+	}
+}
+
+// ChildAttributes returns the attributes of the span of a child resource.
+func ChildAttributes(child client.Object) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("child.kind", child.GetObjectKind().GroupVersionKind().Kind),
+		attribute.String("child.namespace", child.GetNamespace()),
+		attribute.String("child.name", child.GetName()),
+	}
+}
+`
@@ -14,6 +14,7 @@ const (
 	importMarker    = "imports"
 	addSchemeMarker = "scheme"
 	setupMarker     = "reconcilers"
+	webhookMarker   = "webhooks"
 )
 
 var _ machinery.Template = &Main{}
@@ -34,6 +35,7 @@ func (f *Main) SetTemplateDefaults() error {
 		machinery.NewMarkerFor(f.Path, importMarker),
 		machinery.NewMarkerFor(f.Path, addSchemeMarker),
 		machinery.NewMarkerFor(f.Path, setupMarker),
+		machinery.NewMarkerFor(f.Path, webhookMarker),
 	)
 
 	f.IfExistsAction = machinery.OverwriteFile
@@ -65,6 +67,7 @@ func (f *MainUpdater) GetMarkers() []machinery.Marker {
 		machinery.NewMarkerFor(defaultMainPath, importMarker),
 		machinery.NewMarkerFor(defaultMainPath, addSchemeMarker),
 		machinery.NewMarkerFor(defaultMainPath, setupMarker),
+		machinery.NewMarkerFor(defaultMainPath, webhookMarker),
 	}
 }
 
@@ -81,16 +84,12 @@ const (
 `
 	multiGroupReconcilerSetupCodeFragment = `%scontrollers.New%sReconciler(mgr),
 `
-	webhookSetupCodeFragment = `
-if err = (&%s.%s{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "%s")
-		os.Exit(1)
-	}
+	webhookSetupCodeFragment = `&%s.%s{},
 `
 )
 
 func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
-	const options = 3
+	const options = 4
 
 	fragments := make(machinery.CodeFragmentsMap, options)
 
@@ -137,9 +136,10 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		}
 	}
 
+	// Generate webhook setup code fragments
+	webhooks := make([]string, 0)
 	if f.WireWebhook {
-		setup = append(setup, fmt.Sprintf(webhookSetupCodeFragment,
-			f.Resource.ImportAlias(), f.Resource.Kind, f.Resource.Kind))
+		webhooks = append(webhooks, fmt.Sprintf(webhookSetupCodeFragment, f.Resource.ImportAlias(), f.Resource.Kind))
 	}
 
 	// Only store code fragments in the map if the slices are non-empty
@@ -155,6 +155,10 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		fragments[machinery.NewMarkerFor(defaultMainPath, setupMarker)] = setup
 	}
 
+	if len(webhooks) != 0 {
+		fragments[machinery.NewMarkerFor(defaultMainPath, webhookMarker)] = webhooks
+	}
+
 	return fragments
 }
 
@@ -163,7 +167,9 @@ const mainTemplate = `{{ .Boilerplate }}
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -178,6 +184,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"{{ .Repo }}/internal/config"
+	"{{ .Repo }}/internal/requeue"
+	"{{ .Repo }}/internal/scope"
+	"{{ .Repo }}/internal/tracing"
 	%s
 )
 
@@ -186,6 +197,10 @@ type ReconcilerInitializer interface {
 	SetupWithManager(ctrl.Manager) error
 }
 
+type WebhookInitializer interface {
+	SetupWebhookWithManager(ctrl.Manager) error
+}
+
 var (
 	scheme = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -218,6 +233,19 @@ func main() {
 		"Command-line flags override configuration from this file.")
 {{- end }}
 
+	var tracingOptions tracing.Options
+
+	flag.StringVar(&tracingOptions.Exporter, "tracing-exporter", tracing.ExporterNone,
+		"The exporter of the spans of each reconciliation, which is one of none, otlp or stdout.")
+	flag.StringVar(&tracingOptions.Endpoint, "tracing-endpoint", "localhost:4317",
+		"The address of the OTLP collector which the spans are exported to.")
+	flag.BoolVar(&tracingOptions.Insecure, "tracing-insecure", false,
+		"Disable transport security for the connection to the OTLP collector.")
+
+	config.BindFlags(flag.CommandLine)
+	requeue.BindFlags(flag.CommandLine)
+	scope.BindFlags(flag.CommandLine)
+
 	opts := zap.Options{
 		Development: true,
 	}
@@ -233,28 +261,35 @@ func main() {
 		}),
 	)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 {{ if not .ComponentConfig }}
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	options := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "{{ hashFNV .Repo }}.{{ .Domain }}",
-	})
+	}
 {{- else }}
-	var err error
 	options := ctrl.Options{Scheme: scheme}
 	if configFile != "" {
-		options, err = options.AndFrom(ctrl.ConfigFile().AtPath(configFile))
-		if err != nil {
+		if err := config.Load(configFile, &options); err != nil {
 			setupLog.Error(err, "unable to load the config file")
 			os.Exit(1)
 		}
 	}
+{{- end }}
+
+	// restrict the manager to the namespaces of the watch-namespaces flag, when it is set
+	scope.SetCache(&options)
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
-{{- end }}
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -271,6 +306,17 @@ func main() {
 		}
 	}
 
+	webhooks := []WebhookInitializer{
+		%s
+	}
+
+	for _, webhook := range webhooks {
+		if err = webhook.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", fmt.Sprintf("%%T", webhook))
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -287,5 +333,10 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// export the spans which have not yet been exported
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to shut down tracing")
+	}
 }
 `
@@ -9,7 +9,7 @@ import (
 
 var _ machinery.Template = &Makefile{}
 
-const crdOptions = "crd:preserveUnknownFields=false,crdVersions=v1,trivialVersions=true"
+const crdOptions = "crd"
 
 // Makefile scaffolds the project Makefile.
 type Makefile struct {
@@ -37,8 +37,11 @@ func (f *Makefile) SetTemplateDefaults() error {
 const makefileTemplate = `
 # Image URL to use all building/pushing image targets
 IMG ?= controller:latest
-# Produce CRDs that work back to Kubernetes 1.11 (no version conversion)
+# Produce v1 CRDs, which are the only CRDs supported by controller-gen v0.9 and later
 CRD_OPTIONS ?= "{{ .CrdOptions }}"
+# The namespaces, other than its own, to which the namespaced roles of the manager are deployed, e.g. tenant-a,tenant-b
+WATCH_NAMESPACES ?=
+comma := ,
 
 # Get the currently used golang install path (in GOPATH/bin, unless GOBIN is set)
 ifeq (,$(shell go env GOBIN))
@@ -123,10 +126,20 @@ deploy: manifests kustomize ## Deploy controller to the K8s cluster specified in
 undeploy: ## Undeploy controller from the K8s cluster specified in ~/.kube/config.
 	$(KUSTOMIZE) build config/default | kubectl delete -f -
 
+deploy-namespaced-rbac: manifests kustomize ## Deploy the namespaced roles of the manager to each namespace of WATCH_NAMESPACES.
+	for namespace in $(subst $(comma), ,$(WATCH_NAMESPACES)); do \
+		$(KUSTOMIZE) build config/rbac/watch-namespace | kubectl apply --namespace=$$namespace -f -; \
+	done
+
+undeploy-namespaced-rbac: kustomize ## Remove the namespaced roles of the manager from each namespace of WATCH_NAMESPACES.
+	for namespace in $(subst $(comma), ,$(WATCH_NAMESPACES)); do \
+		$(KUSTOMIZE) build config/rbac/watch-namespace | kubectl delete --namespace=$$namespace --ignore-not-found -f -; \
+	done
+
 
 CONTROLLER_GEN = $(shell pwd)/bin/controller-gen
 controller-gen: ## Download controller-gen locally if necessary.
-	$(call go-get-tool,$(CONTROLLER_GEN),sigs.k8s.io/controller-tools/cmd/controller-gen@v0.4.1)
+	$(call go-get-tool,$(CONTROLLER_GEN),sigs.k8s.io/controller-tools/cmd/controller-gen@v0.9.2)
 
 KUSTOMIZE = $(shell pwd)/bin/kustomize
 kustomize: ## Download kustomize locally if necessary.
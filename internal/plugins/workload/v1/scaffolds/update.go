@@ -0,0 +1,205 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/v3/pkg/plugins"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/api"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/api/resources"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/samples"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/controller"
+	intconfig "github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/config"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/metrics"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/requeue"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/scope"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/int/tracing"
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var _ plugins.Scaffolder = &apiUpdateScaffolder{}
+
+var (
+	ErrUpdateAPINotCreated = errors.New("api has not been created; run create api first")
+	ErrScaffoldRBAC        = errors.New("error scaffolding controller rbac markers")
+)
+
+type apiUpdateScaffolder struct {
+	fs machinery.Filesystem
+
+	config      config.Config
+	boilerplate string
+	workload    workloadv1.WorkloadAPIBuilder
+}
+
+// NewAPIUpdateScaffolder returns a new Scaffolder for regenerating the portions of
+// existing apis which are derived from the workload config and its manifests.
+func NewAPIUpdateScaffolder(
+	cfg config.Config,
+	workload workloadv1.WorkloadAPIBuilder,
+) plugins.Scaffolder {
+	return &apiUpdateScaffolder{
+		config:   cfg,
+		workload: workload,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder.
+func (s *apiUpdateScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = machinery.Filesystem{FS: newUserRegionFs(fs.FS, nil)}
+}
+
+// Scaffold implements cmdutil.Scaffolder.
+func (s *apiUpdateScaffolder) Scaffold() error {
+	log.Println("Updating API...")
+
+	boilerplate, err := afero.ReadFile(s.fs.FS, boilerplatePath)
+	if err != nil {
+		return fmt.Errorf("unable to read boilerplate file %s, %w", boilerplatePath, err)
+	}
+
+	s.boilerplate = string(boilerplate)
+
+	if err := s.updateWorkload(s.workload); err != nil {
+		return fmt.Errorf("%w; %s for workload type %T", err, ErrScaffoldWorkload, s.workload)
+	}
+
+	return nil
+}
+
+// updateWorkload regenerates the child resource definitions, spec types, samples and
+// rbac markers for an individual workload and, for a collection, its components.  The
+// controller and any other files owned by the user are not written.
+func (s *apiUpdateScaffolder) updateWorkload(workload workloadv1.WorkloadAPIBuilder) error {
+	res, err := s.resource(workload)
+	if err != nil {
+		return err
+	}
+
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+		machinery.WithBoilerplate(s.boilerplate),
+		machinery.WithResource(res),
+	)
+
+	// keep the storage version of a kind which is served at multiple versions
+	storageVersion, err := hasStorageVersion(s.fs.FS, res)
+	if err != nil {
+		return err
+	}
+
+	if err := scaffold.Execute(&api.Types{Builder: workload, StorageVersion: storageVersion}); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldAPITypes)
+	}
+
+	if err := scaffold.Execute(
+		&resources.Resources{Builder: workload},
+		&resources.Status{Builder: workload},
+		&resources.Ready{Builder: workload},
+		&resources.Delete{Builder: workload},
+		&resources.Apply{Builder: workload},
+		&api.ConfigGroup{},
+		&api.ConfigTypes{},
+		&intconfig.Config{},
+		&metrics.Metrics{},
+		&tracing.Tracing{},
+		&requeue.Requeue{},
+		&scope.Scope{},
+	); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldAPIResources)
+	}
+
+	for _, sourceFile := range *workload.GetSourceFiles() {
+		if err := scaffold.Execute(
+			&resources.Definition{Builder: workload, SourceFile: sourceFile},
+		); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrScaffoldAPIChildResources)
+		}
+	}
+
+	if workload.HasWebhooks() || res.HasConversionWebhook() {
+		if err := s.updateWebhooks(scaffold, workload, res); err != nil {
+			return err
+		}
+	}
+
+	if err := scaffold.Execute(&controller.RBAC{Builder: workload}); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldRBAC)
+	}
+
+	if !workload.IsClusterScoped() {
+		if err := scaffoldNamespacedRBAC(s.fs.FS, scaffold, workload); err != nil {
+			return err
+		}
+	}
+
+	if err := scaffold.Execute(
+		&samples.CRDSample{
+			SpecFields:      workload.GetAPISpecFields(),
+			IsClusterScoped: workload.IsClusterScoped(),
+		},
+	); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldCRDSample)
+	}
+
+	if workload.IsCollection() {
+		for _, component := range workload.GetComponents() {
+			if err := s.updateWorkload(component); err != nil {
+				return fmt.Errorf("%w; %s for workload type %T", err, ErrScaffoldWorkload, component)
+			}
+		}
+	}
+
+	return nil
+}
+
+// updateWebhooks regenerates the webhooks of a workload and registers them with the
+// manager, which enables webhooks for an existing api.
+func (s *apiUpdateScaffolder) updateWebhooks(
+	scaffold *machinery.Scaffold,
+	workload workloadv1.WorkloadAPIBuilder,
+	res *resource.Resource,
+) error {
+	if err := scaffold.Execute(&api.Webhook{Builder: workload}); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldWebhooks)
+	}
+
+	if workload.HasWebhooks() {
+		if err := scaffoldWebhookConfig(s.fs.FS, s.config, scaffold, res); err != nil {
+			return err
+		}
+	}
+
+	if err := scaffold.Execute(&templates.MainUpdater{WireWebhook: true}); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldMainUpdater)
+	}
+
+	return nil
+}
+
+// resource returns the resource of a workload as it is recorded in the project.
+func (s *apiUpdateScaffolder) resource(workload workloadv1.WorkloadAPIBuilder) (*resource.Resource, error) {
+	gvk := resource.GVK{
+		Domain:  s.config.GetDomain(),
+		Group:   workload.GetAPIGroup(),
+		Version: workload.GetAPIVersion(),
+		Kind:    workload.GetAPIKind(),
+	}
+
+	res, err := s.config.GetResource(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s/%s, Kind=%s", ErrUpdateAPINotCreated, gvk.Group, gvk.Version, gvk.Kind)
+	}
+
+	return &res, nil
+}
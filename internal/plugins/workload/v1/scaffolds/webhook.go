@@ -0,0 +1,179 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/certmanager"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/kdefault"
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds/templates/config/webhook"
+)
+
+var ErrScaffoldWebhooks = errors.New("error scaffolding webhooks")
+
+var (
+	defaultKustomizationPath = filepath.Join("config", "default", "kustomization.yaml")
+	crdKustomizationPath     = filepath.Join("config", "crd", "kustomization.yaml")
+)
+
+// scaffoldWebhookConfig scaffolds the kustomize configuration which serves the validating
+// webhook of a kind, using a certificate issued by cert-manager, and
+// records the webhooks for the resource in the project.
+func scaffoldWebhookConfig(
+	fs afero.Fs,
+	cfg config.Config,
+	scaffold *machinery.Scaffold,
+	res *resource.Resource,
+) error {
+	if err := scaffold.Execute(
+		&webhook.Kustomization{},
+		&webhook.KustomizeConfig{},
+		&webhook.Service{},
+		&certmanager.Certificate{},
+		&certmanager.Kustomization{},
+		&certmanager.KustomizeConfig{},
+		&kdefault.ManagerWebhookPatch{},
+		&kdefault.WebhookCAInjectionPatch{},
+	); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldWebhooks)
+	}
+
+	if err := uncommentLines(fs, defaultKustomizationPath, defaultWebhookLines()); err != nil {
+		return err
+	}
+
+	// the conversion webhook of a kind is served along with the admission webhooks once
+	// the webhook server is enabled
+	if res.HasConversionWebhook() {
+		if err := uncommentLines(fs, crdKustomizationPath, crdWebhookLines(fs, res)); err != nil {
+			return err
+		}
+	}
+
+	if res.Webhooks == nil {
+		res.Webhooks = &resource.Webhooks{}
+	}
+
+	res.Webhooks.WebhookVersion = webhookVersion
+	res.Webhooks.Validation = true
+
+	if err := cfg.UpdateResource(*res); err != nil {
+		return fmt.Errorf("unable to record webhooks for %s, %w", res.Kind, err)
+	}
+
+	return nil
+}
+
+// defaultWebhookLines returns a function which determines if a commented line of the
+// default kustomization enables the webhook server or its certificate.  This includes
+// each variable of the vars block, which are used to inject the certificate.
+func defaultWebhookLines() func(line string) bool {
+	var inVars bool
+
+	return func(line string) bool {
+		if line == "vars:" {
+			inVars = true
+
+			return false
+		}
+
+		if inVars {
+			return strings.HasPrefix(line, "#- ") || strings.HasPrefix(line, "#  ")
+		}
+
+		switch line {
+		case "#- ../webhook", "#- ../certmanager", "#- manager_webhook_patch.yaml", "#- webhookcainjection_patch.yaml":
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// crdWebhookLines returns a function which determines if a commented line of the crd
+// kustomization enables a patch of the crd of a resource, which exists, to use the
+// conversion webhook.
+func crdWebhookLines(fs afero.Fs, res *resource.Resource) func(line string) bool {
+	patches := []string{}
+
+	for _, patch := range []string{
+		fmt.Sprintf("patches/webhook_in_%s.yaml", res.Plural),
+		fmt.Sprintf("patches/cainjection_in_%s.yaml", res.Plural),
+	} {
+		// the patches are only scaffolded for resources created directly by create api
+		if exists, err := afero.Exists(fs, filepath.Join("config", "crd", patch)); err == nil && exists {
+			patches = append(patches, "#- "+patch)
+		}
+	}
+
+	return func(line string) bool {
+		return containsLine(patches, line)
+	}
+}
+
+// uncommentLines removes the leading comment character from each line of a file for
+// which uncomment returns true.  A commented list entry which already exists uncommented
+// is removed rather than duplicated, as other scaffolders may insert the commented entry
+// again.  A file which does not exist is ignored, as the file is scaffolded by another
+// plugin.
+func uncommentLines(fs afero.Fs, path string, uncomment func(line string) bool) error {
+	info, err := fs.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to read %s, %w", path, err)
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s, %w", path, err)
+	}
+
+	existing := strings.Split(string(content), "\n")
+	lines := make([]string, 0, len(existing))
+
+	for _, line := range existing {
+		if !uncomment(line) {
+			lines = append(lines, line)
+
+			continue
+		}
+
+		uncommented := strings.TrimPrefix(line, "#")
+
+		if strings.HasPrefix(uncommented, "- ") && containsLine(existing, uncommented) {
+			continue
+		}
+
+		lines = append(lines, uncommented)
+	}
+
+	if err := afero.WriteFile(fs, path, []byte(strings.Join(lines, "\n")), info.Mode()); err != nil {
+		return fmt.Errorf("unable to write %s, %w", path, err)
+	}
+
+	return nil
+}
+
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1/scaffolds"
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+// UpdateAPI regenerates the portions of the existing apis of a project which are
+// derived from the workload config recorded in the PROJECT file.  When preview is
+// provided, the files are not written and are instead previewed to it.
+func UpdateAPI(fs machinery.Filesystem, preview io.Writer) error {
+	store := yaml.New(fs)
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("unable to load project configuration, %w", err)
+	}
+
+	var pluginConfig workloadv1.PluginConfig
+	if err := store.Config().DecodePluginConfig(workloadv1.PluginConfigKey, &pluginConfig); err != nil {
+		return fmt.Errorf("unable to decode operatorbuilder config key, %w", err)
+	}
+
+	workload, err := workloadv1.ProcessAPIConfig(pluginConfig.WorkloadConfigPath)
+	if err != nil {
+		return fmt.Errorf("unable to process api config for %s, %w", pluginConfig.WorkloadConfigPath, err)
+	}
+
+	if err := workload.Validate(); err != nil {
+		return fmt.Errorf("unable to validate config %s, %w", pluginConfig.WorkloadConfigPath, err)
+	}
+
+	scaffolder := scaffolds.NewAPIUpdateScaffolder(store.Config(), workload)
+
+	if preview == nil {
+		scaffolder.InjectFS(fs)
+
+		if err := scaffolder.Scaffold(); err != nil {
+			return fmt.Errorf("unable to update api, %w", err)
+		}
+
+		return nil
+	}
+
+	p := scaffolds.NewPreview(fs)
+	scaffolder.InjectFS(p.FS())
+
+	if err := scaffolder.Scaffold(); err != nil {
+		return fmt.Errorf("unable to update api, %w", err)
+	}
+
+	if err := p.Write(preview); err != nil {
+		return fmt.Errorf("unable to write preview of api update, %w", err)
+	}
+
+	return nil
+}
@@ -41,3 +41,14 @@ func ToFileName(name string) string {
 func ToPackageName(name string) string {
 	return strings.ToLower(strings.Replace(name, "-", "", -1))
 }
+
+// ToCamelCase will convert a kebab-case string to a camelCase name appropriate to
+// use as a workload field name.
+func ToCamelCase(name string) string {
+	pascal := ToPascalCase(name)
+	if pascal == "" {
+		return pascal
+	}
+
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
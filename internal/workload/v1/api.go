@@ -26,6 +26,19 @@ type APIFields struct {
 	Default      string
 	Sample       string
 	Last         bool
+
+	// RequiredIf and MutuallyExclusive are the cross-field validation rules of the field
+	// which are enforced by the validating webhook.
+	RequiredIf        string
+	MutuallyExclusive string
+
+	// Validations are the CEL validation rules of the field which are validated by the
+	// API server.
+	Validations []string
+
+	// PrinterColumn is the column under which the field is displayed by kubectl get, if
+	// any.
+	PrinterColumn *PrinterColumn
 }
 
 func (api *APIFields) AddField(path string, fieldType FieldType, comments []string, sample interface{}, hasDefault bool) error {
@@ -92,9 +105,18 @@ func (api *APIFields) GenerateAPISpec(kind string) string {
 
 	mustWrite(buf.WriteString(fmt.Sprintf(`
 // %[1]sSpec defines the desired state of %[1]s.
-type %[1]sSpec struct {
+`, kind)))
+
+	for _, v := range api.Validations {
+		mustWrite(buf.WriteString(fmt.Sprintf("// %s\n", v)))
+	}
+
+	mustWrite(buf.WriteString(fmt.Sprintf(`type %[1]sSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+	// Fields added within the user region below are preserved when the api is regenerated.
+	//+operator-builder:user:begin:spec
+	//+operator-builder:user:end:spec
 
 `, kind)))
 
@@ -167,6 +189,10 @@ func (api *APIFields) generateAPISpecField(b io.StringWriter, kind string) {
 		mustWrite(b.WriteString(fmt.Sprintf("// %s\n", m)))
 	}
 
+	for _, v := range api.Validations {
+		mustWrite(b.WriteString(fmt.Sprintf("// %s\n", v)))
+	}
+
 	for _, c := range api.Comments {
 		mustWrite(b.WriteString(fmt.Sprintf("// %s\n", c)))
 	}
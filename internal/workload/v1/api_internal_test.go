@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAPIFields_GenerateSampleSpec(t *testing.T) {
@@ -1024,3 +1025,54 @@ func TestAPIFields_AddField(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadSpec_appendPausedField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		fieldType FieldType
+		wantErr   error
+	}{
+		{
+			name: "no field marker",
+		},
+		{
+			name:      "field marker of the same type",
+			fieldType: FieldBool,
+		},
+		{
+			name:      "field marker of another type",
+			fieldType: FieldString,
+			wantErr:   ErrPausedFieldConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{}
+			ws.init()
+
+			assert.Empty(t, ws.APISpecFields.Children)
+
+			if tt.fieldType != FieldUnknownType {
+				require.NoError(t, ws.APISpecFields.AddField("paused", tt.fieldType, nil, true, false))
+			}
+
+			err := ws.appendPausedField()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, ws.APISpecFields.Children, 1)
+			assert.Equal(t, "Paused", ws.APISpecFields.Children[0].Name)
+			assert.Equal(t, FieldBool, ws.APISpecFields.Children[0].Type)
+		})
+	}
+}
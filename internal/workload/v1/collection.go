@@ -26,6 +26,7 @@ type WorkloadCollectionSpec struct {
 	CompanionCliRootcmd CliCommand           `json:"companionCliRootcmd,omitempty" yaml:"companionCliRootcmd,omitempty" validate:"omitempty"`
 	CompanionCliSubcmd  CliCommand           `json:"companionCliSubcmd,omitempty" yaml:"companionCliSubcmd,omitempty" validate:"omitempty"`
 	ComponentFiles      []string             `json:"componentFiles" yaml:"componentFiles"`
+	ComponentDefaults   *ComponentDefaults   `json:"componentDefaults,omitempty" yaml:"componentDefaults,omitempty" validate:"omitempty"`
 	Components          []*ComponentWorkload `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	WorkloadSpec        `yaml:",inline"`
 }
@@ -43,8 +44,9 @@ func NewWorkloadCollection(
 ) *WorkloadCollection {
 	return &WorkloadCollection{
 		WorkloadShared: WorkloadShared{
-			Kind: WorkloadKindCollection,
-			Name: name,
+			APIVersion: WorkloadConfigLatestVersion,
+			Kind:       WorkloadKindCollection,
+			Name:       name,
 		},
 		Spec: WorkloadCollectionSpec{
 			API:            spec,
@@ -130,10 +132,26 @@ func (c *WorkloadCollection) GetAPIKind() string {
 	return c.Spec.API.Kind
 }
 
+func (c *WorkloadCollection) GetAPIShortNames() []string {
+	return c.Spec.API.ShortNames
+}
+
+func (c *WorkloadCollection) GetAPICategories() []string {
+	return c.Spec.API.Categories
+}
+
 func (c *WorkloadCollection) IsClusterScoped() bool {
 	return c.Spec.API.ClusterScoped
 }
 
+func (c *WorkloadCollection) HasWebhooks() bool {
+	return c.Spec.API.Webhooks
+}
+
+func (c *WorkloadCollection) HasPausedField() bool {
+	return c.Spec.API.PausedField
+}
+
 func (c *WorkloadCollection) IsStandalone() bool {
 	return false
 }
@@ -147,7 +165,7 @@ func (c *WorkloadCollection) IsCollection() bool {
 }
 
 func (c *WorkloadCollection) SetResources(workloadPath string) error {
-	err := c.Spec.processManifests(FieldMarkerType, CollectionMarkerType)
+	err := c.Spec.processManifests(FieldMarkerType, CollectionMarkerType, ValidationMarkerType)
 	if err != nil {
 		return err
 	}
@@ -162,6 +180,10 @@ func (c *WorkloadCollection) SetResources(workloadPath string) error {
 		}
 	}
 
+	if c.HasPausedField() {
+		return c.Spec.appendPausedField()
+	}
+
 	return nil
 }
 
@@ -199,14 +221,44 @@ func (c *WorkloadCollection) GetAPISpecFields() *APIFields {
 	return c.Spec.APISpecFields
 }
 
+func (c *WorkloadCollection) GetStatusFields() []*StatusField {
+	return c.Spec.StatusFields
+}
+
+func (c *WorkloadCollection) GetReadyKinds() []string {
+	return c.Spec.ReadyKinds
+}
+
+func (c *WorkloadCollection) GetReadyConditions() []*ReadyCondition {
+	return c.Spec.ReadyConditions
+}
+
+func (c *WorkloadCollection) GetDeleteWaves() []*DeleteWave {
+	return c.Spec.DeleteWaves
+}
+
+func (c *WorkloadCollection) GetDriftRules() []*DriftRule {
+	return c.Spec.DriftRules
+}
+
+func (c *WorkloadCollection) GetRequeueRules() []*RequeueRule {
+	return c.Spec.RequeueRules
+}
+
+func (c *WorkloadCollection) GetControllerOptions() *ControllerOptions {
+	return c.Spec.ControllerOptions
+}
+
 func (c *WorkloadCollection) GetRBACRules() *[]RBACRule {
 	var rules []RBACRule = *c.Spec.RBACRules
 
 	return &rules
 }
 
-func (*WorkloadCollection) GetOwnershipRules() *[]OwnershipRule {
-	return &[]OwnershipRule{}
+func (c *WorkloadCollection) GetOwnershipRules() *[]OwnershipRule {
+	var rules []OwnershipRule = *c.Spec.OwnershipRules
+
+	return &rules
 }
 
 func (c *WorkloadCollection) GetComponentResource(domain, repo string, clusterScoped bool) *resource.Resource {
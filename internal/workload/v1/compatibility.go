@@ -0,0 +1,298 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	optionalMarker = "+kubebuilder:validation:Optional"
+	defaultMarker  = "+kubebuilder:default="
+	enumMarker     = "+kubebuilder:validation:Enum="
+)
+
+// APIChangeType describes a change to the spec of a kind between two versions.
+type APIChangeType int
+
+const (
+	KindRemoved APIChangeType = iota
+	KindAdded
+	FieldRemoved
+	FieldTypeChanged
+	RequiredFieldAdded
+	OptionalFieldAdded
+	FieldMadeRequired
+	FieldMadeOptional
+	DefaultAdded
+	DefaultRemoved
+	DefaultChanged
+	EnumNarrowed
+	EnumWidened
+)
+
+func (t APIChangeType) String() string {
+	types := map[APIChangeType]string{
+		KindRemoved:        "kind removed",
+		KindAdded:          "kind added",
+		FieldRemoved:       "field removed",
+		FieldTypeChanged:   "field type changed",
+		RequiredFieldAdded: "required field added",
+		OptionalFieldAdded: "optional field added",
+		FieldMadeRequired:  "field made required",
+		FieldMadeOptional:  "field made optional",
+		DefaultAdded:       "default added",
+		DefaultRemoved:     "default removed",
+		DefaultChanged:     "default changed",
+		EnumNarrowed:       "enum narrowed",
+		EnumWidened:        "enum widened",
+	}
+
+	return types[t]
+}
+
+// Breaking determines if a change prevents manifests which are valid for the previous
+// version from being used, unchanged and with the same outcome, with the current version
+// according to the Kubernetes API conventions.
+func (t APIChangeType) Breaking() bool {
+	switch t {
+	case KindRemoved, FieldRemoved, FieldTypeChanged, RequiredFieldAdded,
+		FieldMadeRequired, DefaultRemoved, DefaultChanged, EnumNarrowed:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIChange is a single difference in the spec of a kind between two versions.
+type APIChange struct {
+	Kind   string
+	Path   string
+	Type   APIChangeType
+	Detail string
+}
+
+func (c *APIChange) String() string {
+	severity := "non-breaking"
+	if c.Type.Breaking() {
+		severity = "BREAKING"
+	}
+
+	change := fmt.Sprintf("%-12s %s %s: %s", severity, c.Kind, c.Path, c.Type)
+	if c.Detail != "" {
+		change = fmt.Sprintf("%s (%s)", change, c.Detail)
+	}
+
+	return change
+}
+
+// CompareWorkloads compares the specs of each kind, including the components of a
+// collection, between a previous and a current workload.
+func CompareWorkloads(previous, current WorkloadAPIBuilder) []*APIChange {
+	previousKinds := workloadKinds(previous)
+	currentKinds := workloadKinds(current)
+
+	changes := []*APIChange{}
+
+	for _, kind := range sortedKinds(previousKinds) {
+		if _, ok := currentKinds[kind]; !ok {
+			changes = append(changes, &APIChange{Kind: kind, Path: "spec", Type: KindRemoved})
+
+			continue
+		}
+
+		changes = append(changes, CompareAPIFields(kind, previousKinds[kind], currentKinds[kind])...)
+	}
+
+	for _, kind := range sortedKinds(currentKinds) {
+		if _, ok := previousKinds[kind]; !ok {
+			changes = append(changes, &APIChange{Kind: kind, Path: "spec", Type: KindAdded})
+		}
+	}
+
+	return changes
+}
+
+// CompareAPIFields compares the spec of a kind between a previous and a current version.
+func CompareAPIFields(kind string, previous, current *APIFields) []*APIChange {
+	changes := []*APIChange{}
+
+	compareAPIFields(&changes, kind, "spec", previous, current)
+
+	return changes
+}
+
+func compareAPIFields(changes *[]*APIChange, kind, path string, previous, current *APIFields) {
+	for _, previousChild := range previous.Children {
+		childPath := fmt.Sprintf("%s.%s", path, previousChild.jsonName())
+
+		currentChild := current.getChild(previousChild.Name)
+		if currentChild == nil {
+			*changes = append(*changes, &APIChange{Kind: kind, Path: childPath, Type: FieldRemoved})
+
+			continue
+		}
+
+		if previousChild.Type != currentChild.Type {
+			*changes = append(*changes, &APIChange{
+				Kind:   kind,
+				Path:   childPath,
+				Type:   FieldTypeChanged,
+				Detail: fmt.Sprintf("%s to %s", previousChild.Type, currentChild.Type),
+			})
+
+			continue
+		}
+
+		if currentChild.Type == FieldStruct {
+			compareAPIFields(changes, kind, childPath, previousChild, currentChild)
+
+			continue
+		}
+
+		compareAPIField(changes, kind, childPath, previousChild, currentChild)
+	}
+
+	for _, currentChild := range current.Children {
+		if previous.getChild(currentChild.Name) != nil {
+			continue
+		}
+
+		changeType := OptionalFieldAdded
+		if currentChild.isRequired() {
+			changeType = RequiredFieldAdded
+		}
+
+		*changes = append(*changes, &APIChange{
+			Kind: kind,
+			Path: fmt.Sprintf("%s.%s", path, currentChild.jsonName()),
+			Type: changeType,
+		})
+	}
+}
+
+func compareAPIField(changes *[]*APIChange, kind, path string, previous, current *APIFields) {
+	add := func(changeType APIChangeType, detail string) {
+		*changes = append(*changes, &APIChange{Kind: kind, Path: path, Type: changeType, Detail: detail})
+	}
+
+	switch previousRequired, currentRequired := previous.isRequired(), current.isRequired(); {
+	case !previousRequired && currentRequired:
+		add(FieldMadeRequired, "")
+	case previousRequired && !currentRequired:
+		add(FieldMadeOptional, "")
+	}
+
+	switch previousDefault, currentDefault := previous.defaultValue(), current.defaultValue(); {
+	case previousDefault == currentDefault:
+	case previousDefault == "":
+		add(DefaultAdded, currentDefault)
+	case currentDefault == "":
+		add(DefaultRemoved, previousDefault)
+	default:
+		add(DefaultChanged, fmt.Sprintf("%s to %s", previousDefault, currentDefault))
+	}
+
+	previousEnum, currentEnum := previous.enumValues(), current.enumValues()
+
+	if removed := missingEnumValues(previousEnum, currentEnum); len(removed) > 0 {
+		add(EnumNarrowed, fmt.Sprintf("%s no longer allowed", strings.Join(removed, ", ")))
+	}
+
+	if added := missingEnumValues(currentEnum, previousEnum); len(added) > 0 {
+		add(EnumWidened, fmt.Sprintf("%s now allowed", strings.Join(added, ", ")))
+	}
+}
+
+// missingEnumValues returns the values of an enum which are missing from another.  An
+// empty enum allows any value.
+func missingEnumValues(enum, other []string) []string {
+	missing := []string{}
+
+	if len(enum) == 0 || len(other) == 0 {
+		return missing
+	}
+
+	for _, value := range enum {
+		if !containsString(other, value) {
+			missing = append(missing, value)
+		}
+	}
+
+	return missing
+}
+
+func (api *APIFields) jsonName() string {
+	if api.manifestName != "" {
+		return api.manifestName
+	}
+
+	return api.Name
+}
+
+func (api *APIFields) isRequired() bool {
+	if api.defaultValue() != "" {
+		return false
+	}
+
+	for _, marker := range api.Markers {
+		if marker == optionalMarker {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (api *APIFields) defaultValue() string {
+	if api.Default != "" {
+		return api.Default
+	}
+
+	for _, marker := range api.Markers {
+		if strings.HasPrefix(marker, defaultMarker) {
+			return strings.TrimPrefix(marker, defaultMarker)
+		}
+	}
+
+	return ""
+}
+
+func (api *APIFields) enumValues() []string {
+	for _, marker := range api.Markers {
+		if strings.HasPrefix(marker, enumMarker) {
+			return strings.Split(strings.TrimPrefix(marker, enumMarker), ";")
+		}
+	}
+
+	return nil
+}
+
+func workloadKinds(workload WorkloadAPIBuilder) map[string]*APIFields {
+	kinds := map[string]*APIFields{
+		workload.GetAPIKind(): workload.GetAPISpecFields(),
+	}
+
+	if workload.IsCollection() {
+		for _, component := range workload.GetComponents() {
+			kinds[component.GetAPIKind()] = component.GetAPISpecFields()
+		}
+	}
+
+	return kinds
+}
+
+func sortedKinds(kinds map[string]*APIFields) []string {
+	keys := make([]string, 0, len(kinds))
+
+	for key := range kinds {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareAPIFields(t *testing.T) {
+	t.Parallel()
+
+	previous := &APIFields{}
+	require.NoError(t, previous.AddField("replicas", FieldInt, nil, 1, true))
+	require.NoError(t, previous.AddField("image.name", FieldString, nil, "nginx", true))
+	require.NoError(t, previous.AddField("image.tag", FieldString, nil, "latest", false))
+	require.NoError(t, previous.AddField("port", FieldInt, nil, 80, false))
+	require.NoError(t, previous.AddField("motd", FieldString, nil, "hello", false))
+	require.NoError(t, previous.AddField("tier", FieldString, nil, "web", false))
+
+	current := &APIFields{}
+	require.NoError(t, current.AddField("replicas", FieldInt, nil, 3, true))
+	require.NoError(t, current.AddField("image.name", FieldString, nil, "nginx", true))
+	require.NoError(t, current.AddField("image.tag", FieldString, nil, "latest", true))
+	require.NoError(t, current.AddField("port", FieldString, nil, "http", false))
+	require.NoError(t, current.AddField("tier", FieldString, nil, "web", false))
+	require.NoError(t, current.AddField("host", FieldString, nil, "example.com", false))
+	require.NoError(t, current.AddField("debug", FieldBool, nil, false, true))
+
+	previousEnum, currentEnum := "web;api", "web;worker"
+	require.NoError(t, previous.setEnum("tier", &previousEnum))
+	require.NoError(t, current.setEnum("tier", &currentEnum))
+
+	want := []*APIChange{
+		{Kind: "WebApp", Path: "spec.replicas", Type: DefaultChanged, Detail: "1 to 3"},
+		{Kind: "WebApp", Path: "spec.image.tag", Type: FieldMadeOptional},
+		{Kind: "WebApp", Path: "spec.image.tag", Type: DefaultAdded, Detail: `"latest"`},
+		{Kind: "WebApp", Path: "spec.port", Type: FieldTypeChanged, Detail: "int to string"},
+		{Kind: "WebApp", Path: "spec.motd", Type: FieldRemoved},
+		{Kind: "WebApp", Path: "spec.tier", Type: EnumNarrowed, Detail: "api no longer allowed"},
+		{Kind: "WebApp", Path: "spec.tier", Type: EnumWidened, Detail: "worker now allowed"},
+		{Kind: "WebApp", Path: "spec.host", Type: RequiredFieldAdded},
+		{Kind: "WebApp", Path: "spec.debug", Type: OptionalFieldAdded},
+	}
+
+	assert.Equal(t, want, CompareAPIFields("WebApp", previous, current))
+}
+
+func TestAPIChangeType_Breaking(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		changeType APIChangeType
+		want       bool
+	}{
+		{name: "kind removed", changeType: KindRemoved, want: true},
+		{name: "kind added", changeType: KindAdded, want: false},
+		{name: "field removed", changeType: FieldRemoved, want: true},
+		{name: "field type changed", changeType: FieldTypeChanged, want: true},
+		{name: "required field added", changeType: RequiredFieldAdded, want: true},
+		{name: "optional field added", changeType: OptionalFieldAdded, want: false},
+		{name: "field made required", changeType: FieldMadeRequired, want: true},
+		{name: "field made optional", changeType: FieldMadeOptional, want: false},
+		{name: "default added", changeType: DefaultAdded, want: false},
+		{name: "default removed", changeType: DefaultRemoved, want: true},
+		{name: "default changed", changeType: DefaultChanged, want: true},
+		{name: "enum narrowed", changeType: EnumNarrowed, want: true},
+		{name: "enum widened", changeType: EnumWidened, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.changeType.Breaking())
+			assert.Equal(t, tt.name, tt.changeType.String())
+		})
+	}
+}
+
+func TestAPIChange_String(t *testing.T) {
+	t.Parallel()
+
+	change := &APIChange{Kind: "WebApp", Path: "spec.port", Type: FieldTypeChanged, Detail: "int to string"}
+	assert.Equal(t, "BREAKING     WebApp spec.port: field type changed (int to string)", change.String())
+
+	change = &APIChange{Kind: "WebApp", Path: "spec.host", Type: OptionalFieldAdded}
+	assert.Equal(t, "non-breaking WebApp spec.host: optional field added", change.String())
+}
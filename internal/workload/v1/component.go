@@ -21,6 +21,7 @@ type ComponentWorkloadSpec struct {
 	CompanionCliSubcmd    CliCommand           `json:"companionCliSubcmd" yaml:"companionCliSubcmd" validate:"omitempty"`
 	CompanionCliRootcmd   CliCommand           `json:"-" yaml:"-" validate:"omitempty"`
 	Dependencies          []string             `json:"dependencies" yaml:"dependencies"`
+	Extends               string               `json:"extends,omitempty" yaml:"extends,omitempty" validate:"omitempty"`
 	ConfigPath            string               `json:"-" yaml:"-" validate:"omitempty"`
 	ComponentDependencies []*ComponentWorkload `json:"-" yaml:"-" validate:"omitempty"`
 	WorkloadSpec          `yaml:",inline"`
@@ -39,11 +40,12 @@ func NewComponentWorkload(
 ) *ComponentWorkload {
 	return &ComponentWorkload{
 		WorkloadShared: WorkloadShared{
-			Kind: WorkloadKindComponent,
-			Name: name,
+			APIVersion: WorkloadConfigLatestVersion,
+			Kind:       WorkloadKindComponent,
+			Name:       name,
 		},
 		Spec: ComponentWorkloadSpec{
-			API: *NewSampleAPISpec(),
+			API: spec,
 			WorkloadSpec: WorkloadSpec{
 				Resources: getResourcesFromFiles(resourceFiles),
 			},
@@ -112,10 +114,26 @@ func (c *ComponentWorkload) GetAPIKind() string {
 	return c.Spec.API.Kind
 }
 
+func (c *ComponentWorkload) GetAPIShortNames() []string {
+	return c.Spec.API.ShortNames
+}
+
+func (c *ComponentWorkload) GetAPICategories() []string {
+	return c.Spec.API.Categories
+}
+
 func (c *ComponentWorkload) IsClusterScoped() bool {
 	return c.Spec.API.ClusterScoped
 }
 
+func (c *ComponentWorkload) HasWebhooks() bool {
+	return c.Spec.API.Webhooks
+}
+
+func (c *ComponentWorkload) HasPausedField() bool {
+	return c.Spec.API.PausedField
+}
+
 func (*ComponentWorkload) IsStandalone() bool {
 	return false
 }
@@ -129,11 +147,15 @@ func (*ComponentWorkload) IsCollection() bool {
 }
 
 func (c *ComponentWorkload) SetResources(workloadPath string) error {
-	err := c.Spec.processManifests(FieldMarkerType)
+	err := c.Spec.processManifests(FieldMarkerType, ValidationMarkerType)
 	if err != nil {
 		return err
 	}
 
+	if c.HasPausedField() {
+		return c.Spec.appendPausedField()
+	}
+
 	return nil
 }
 
@@ -169,6 +191,34 @@ func (c *ComponentWorkload) GetAPISpecFields() *APIFields {
 	return c.Spec.APISpecFields
 }
 
+func (c *ComponentWorkload) GetStatusFields() []*StatusField {
+	return c.Spec.StatusFields
+}
+
+func (c *ComponentWorkload) GetReadyKinds() []string {
+	return c.Spec.ReadyKinds
+}
+
+func (c *ComponentWorkload) GetReadyConditions() []*ReadyCondition {
+	return c.Spec.ReadyConditions
+}
+
+func (c *ComponentWorkload) GetDeleteWaves() []*DeleteWave {
+	return c.Spec.DeleteWaves
+}
+
+func (c *ComponentWorkload) GetDriftRules() []*DriftRule {
+	return c.Spec.DriftRules
+}
+
+func (c *ComponentWorkload) GetRequeueRules() []*RequeueRule {
+	return c.Spec.RequeueRules
+}
+
+func (c *ComponentWorkload) GetControllerOptions() *ControllerOptions {
+	return c.Spec.ControllerOptions
+}
+
 func (c *ComponentWorkload) GetRBACRules() *[]RBACRule {
 	var rules []RBACRule = *c.Spec.RBACRules
 
@@ -0,0 +1,262 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrExtendsCycle          = errors.New("component config extends chain contains a cycle")
+	ErrExtendsInvalidKind    = errors.New("component config may only extend a ComponentWorkload config")
+	ErrExtendsMultipleConfig = errors.New("component config may only extend a file containing a single config")
+	ErrExtendsNoCollection   = errors.New("component config may only extend another config as a component of a collection")
+)
+
+const (
+	extendsKey   = "extends"
+	resourcesKey = "resources"
+)
+
+// ComponentDefaults defines the attributes that are applied to every component of a
+// collection.  Any value set in a component config, or in a config that it extends,
+// takes precedence over the value set here.
+type ComponentDefaults struct {
+	API ComponentDefaultsAPISpec `json:"api,omitempty" yaml:"api,omitempty"`
+}
+
+// ComponentDefaultsAPISpec defines the api attributes which may be defaulted for the
+// components of a collection.  The kind is intentionally omitted as it must be unique
+// for each component.
+type ComponentDefaultsAPISpec struct {
+	Domain        *string `json:"domain,omitempty" yaml:"domain,omitempty"`
+	Group         *string `json:"group,omitempty" yaml:"group,omitempty"`
+	Version       *string `json:"version,omitempty" yaml:"version,omitempty"`
+	ClusterScoped *bool   `json:"clusterScoped,omitempty" yaml:"clusterScoped,omitempty"`
+}
+
+// resolveComponentConfig returns the content of a component config after the configs
+// that it extends and the collection component defaults have been merged into it.  The
+// extends field is removed once it has been resolved.
+// Values are chosen in the following order of precedence:
+//  1. the component config itself
+//  2. the config named by spec.extends (and, recursively, any config that it extends)
+//  3. the componentDefaults of the collection
+func resolveComponentConfig(componentPath string, defaults *ComponentDefaults) ([]byte, error) {
+	content, err := os.ReadFile(componentPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read component config %s, %w", componentPath, err)
+	}
+
+	documents, err := decodeConfigNodes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", componentPath, err)
+	}
+
+	defaultsNode, err := defaults.toNode()
+	if err != nil {
+		return nil, fmt.Errorf("unable to process component defaults for %s, %w", componentPath, err)
+	}
+
+	for _, document := range documents {
+		mapping := documentMapping(document)
+		if mapping == nil || !isComponentMapping(mapping) {
+			continue
+		}
+
+		spec := mappingValue(mapping, specKey)
+		if spec == nil {
+			spec = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+
+		resolved, err := resolveExtends(componentPath, spec, []string{})
+		if err != nil {
+			return nil, err
+		}
+
+		merged := mergeConfigNodes(defaultsNode, resolved)
+		removeMappingValue(merged, extendsKey)
+
+		setMappingValue(mapping, specKey, merged)
+	}
+
+	return encodeConfigNodes(documents)
+}
+
+// resolveExtends merges the spec of the config named by the extends field of a component
+// spec into the component spec.  The chain of configs visited so far is tracked so that a
+// config which extends itself, either directly or indirectly, results in an error.
+func resolveExtends(configPath string, spec *yaml.Node, chain []string) (*yaml.Node, error) {
+	chain = append(chain, filepath.Clean(configPath))
+
+	extends := mappingValue(spec, extendsKey)
+	if extends == nil || extends.Value == "" {
+		return spec, nil
+	}
+
+	basePath := filepath.Clean(filepath.Join(filepath.Dir(configPath), extends.Value))
+
+	for _, visited := range chain {
+		if visited == basePath {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrExtendsCycle, strings.Join(chain, " -> "), basePath)
+		}
+	}
+
+	baseSpec, err := readBaseSpec(configPath, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	baseSpec, err = resolveExtends(basePath, baseSpec, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	// the extends field is never inherited and inherited resources must be made relative
+	// to the config that is extending the base config
+	removeMappingValue(baseSpec, extendsKey)
+
+	if err := rebaseResources(baseSpec, filepath.Dir(basePath), filepath.Dir(configPath)); err != nil {
+		return nil, fmt.Errorf("unable to inherit resources from %s for %s, %w", basePath, configPath, err)
+	}
+
+	return mergeConfigNodes(baseSpec, spec), nil
+}
+
+// readBaseSpec reads the spec of a config that is extended by another component config.
+func readBaseSpec(configPath, basePath string) (*yaml.Node, error) {
+	content, err := os.ReadFile(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config %s extended by %s, %w", basePath, configPath, err)
+	}
+
+	documents, err := decodeConfigNodes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", basePath, err)
+	}
+
+	if len(documents) != 1 {
+		return nil, fmt.Errorf("%w; %s extended by %s contains %d configs",
+			ErrExtendsMultipleConfig, basePath, configPath, len(documents))
+	}
+
+	mapping := documentMapping(documents[0])
+	if mapping == nil || !isComponentMapping(mapping) {
+		return nil, fmt.Errorf("%w; %s extended by %s", ErrExtendsInvalidKind, basePath, configPath)
+	}
+
+	spec := mappingValue(mapping, specKey)
+	if spec == nil {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+
+	return spec, nil
+}
+
+// rebaseResources rewrites the resource paths of an extended config so that they are
+// relative to the directory of the config which extends it.
+func rebaseResources(spec *yaml.Node, fromDir, toDir string) error {
+	resources := mappingValue(spec, resourcesKey)
+	if resources == nil || resources.Kind != yaml.SequenceNode || fromDir == toDir {
+		return nil
+	}
+
+	for _, resource := range resources.Content {
+		rebased, err := filepath.Rel(toDir, filepath.Join(fromDir, resource.Value))
+		if err != nil {
+			return fmt.Errorf("unable to determine relative file path, %w", err)
+		}
+
+		resource.Value = rebased
+	}
+
+	return nil
+}
+
+// mergeConfigNodes merges two yaml nodes and returns the result.  Mappings are merged
+// recursively with the values from the override taking precedence over the values from
+// the base.  Any other node type in the override, including sequences, replaces the base
+// node entirely.
+func mergeConfigNodes(base, override *yaml.Node) *yaml.Node {
+	if base == nil {
+		return override
+	}
+
+	if override == nil {
+		return base
+	}
+
+	if base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		return override
+	}
+
+	merged := *override
+	merged.Content = make([]*yaml.Node, 0, len(override.Content)+len(base.Content))
+
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+
+		merged.Content = append(merged.Content, key, mergeConfigNodes(mappingValue(base, key.Value), value))
+	}
+
+	for i := 0; i+1 < len(base.Content); i += 2 {
+		if mappingValue(override, base.Content[i].Value) == nil {
+			merged.Content = append(merged.Content, base.Content[i], base.Content[i+1])
+		}
+	}
+
+	return &merged
+}
+
+// toNode converts the component defaults to a yaml node containing only the values
+// which have been explicitly set.
+func (defaults *ComponentDefaults) toNode() (*yaml.Node, error) {
+	if defaults == nil {
+		return nil, nil
+	}
+
+	var node yaml.Node
+	if err := node.Encode(defaults); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return &node, nil
+}
+
+func isComponentMapping(mapping *yaml.Node) bool {
+	kind := mappingValue(mapping, kindKey)
+
+	return kind != nil && kind.Value == WorkloadKindComponent.String()
+}
+
+// setMappingValue sets the value node for a key within a yaml mapping, adding the key
+// if it does not yet exist.
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// removeMappingValue removes a key and its value from a yaml mapping.
+func removeMappingValue(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_mergeConfigNodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		base     string
+		override string
+		expected string
+	}{
+		{
+			name: "override values take precedence",
+			base: `api:
+  group: base
+  clusterScoped: true
+`,
+			override: `api:
+  group: override
+  clusterScoped: false
+`,
+			expected: `api:
+  group: override
+  clusterScoped: false
+`,
+		},
+		{
+			name: "missing values are inherited",
+			base: `api:
+  domain: acme.com
+  group: base
+dependencies:
+  - base-component
+`,
+			override: `api:
+  kind: Override
+`,
+			expected: `api:
+  kind: Override
+  domain: acme.com
+  group: base
+dependencies:
+  - base-component
+`,
+		},
+		{
+			name: "sequences are replaced rather than appended",
+			base: `dependencies:
+  - base-component
+`,
+			override: `dependencies:
+  - override-component
+`,
+			expected: `dependencies:
+  - override-component
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			base, err := decodeConfigNodes([]byte(tt.base))
+			require.NoError(t, err)
+
+			override, err := decodeConfigNodes([]byte(tt.override))
+			require.NoError(t, err)
+
+			merged := mergeConfigNodes(documentMapping(base[0]), documentMapping(override[0]))
+
+			actual, err := encodeConfigNodes([]*yaml.Node{merged})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func Test_resolveComponentConfig(t *testing.T) {
+	t.Parallel()
+
+	clusterScoped := true
+	domain := "acme.com"
+	group := "defaults"
+
+	defaults := &ComponentDefaults{
+		API: ComponentDefaultsAPISpec{
+			Domain:        &domain,
+			Group:         &group,
+			ClusterScoped: &clusterScoped,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "component without extends receives defaults",
+			files: map[string]string{
+				"component.yaml": `name: component
+kind: ComponentWorkload
+spec:
+  api:
+    kind: Component
+`,
+			},
+			expected: `name: component
+kind: ComponentWorkload
+spec:
+  api:
+    kind: Component
+    domain: acme.com
+    group: defaults
+    clusterScoped: true
+`,
+			wantErr: false,
+		},
+		{
+			name: "extended config takes precedence over defaults",
+			files: map[string]string{
+				"component.yaml": `name: component
+kind: ComponentWorkload
+spec:
+  extends: common/base.yaml
+  api:
+    kind: Component
+`,
+				"common/base.yaml": `name: base
+kind: ComponentWorkload
+spec:
+  api:
+    group: base
+    clusterScoped: false
+  resources:
+    - namespace.yaml
+`,
+			},
+			expected: `name: component
+kind: ComponentWorkload
+spec:
+  api:
+    kind: Component
+    group: base
+    clusterScoped: false
+    domain: acme.com
+  resources:
+    - common/namespace.yaml
+`,
+			wantErr: false,
+		},
+		{
+			name: "extends cycle results in an error",
+			files: map[string]string{
+				"component.yaml": `name: component
+kind: ComponentWorkload
+spec:
+  extends: base.yaml
+`,
+				"base.yaml": `name: base
+kind: ComponentWorkload
+spec:
+  extends: component.yaml
+`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "extending a non-component config results in an error",
+			files: map[string]string{
+				"component.yaml": `name: component
+kind: ComponentWorkload
+spec:
+  extends: base.yaml
+`,
+				"base.yaml": `name: base
+kind: StandaloneWorkload
+spec: {}
+`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			}
+
+			actual, err := resolveComponentConfig(filepath.Join(dir, "component.yaml"), defaults)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(actual))
+		})
+	}
+}
+
+func Test_decodeConfig_extendsWithoutCollection(t *testing.T) {
+	t.Parallel()
+
+	component := `name: component
+kind: ComponentWorkload
+spec:
+  extends: base.yaml
+  api:
+    kind: Component
+`
+
+	_, err := decodeConfig("component.yaml", strings.NewReader(component))
+	assert.ErrorIs(t, err, ErrExtendsNoCollection)
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidControllerOptions = errors.New("invalid controller options")
+
+// the defaults of the rate limiter of a controller, which are those of the default rate limiter
+// of controller-runtime.
+const (
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+	defaultRateLimiterQPS       = 10
+	defaultRateLimiterBurst     = 100
+)
+
+// RateLimiterSpec sets the rate limiter of the queue of a controller, which delays a failing
+// workload exponentially from the base delay up to the max delay, and limits all workloads to
+// the queries per second with bursts of up to the burst.
+type RateLimiterSpec struct {
+	BaseDelay string  `json:"baseDelay,omitempty" yaml:"baseDelay,omitempty"`
+	MaxDelay  string  `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty"`
+	QPS       float64 `json:"qps,omitempty" yaml:"qps,omitempty"`
+	Burst     int     `json:"burst,omitempty" yaml:"burst,omitempty"`
+}
+
+// ControllerOptions are the options of the controller of a workload, which are rendered into
+// the controller when it is built.
+type ControllerOptions struct {
+	MaxConcurrentReconciles int
+	RateLimiter             *RateLimiter
+}
+
+// RateLimiter is the rate limiter of a controller with its durations parsed.
+type RateLimiter struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	QPS       float64
+	Burst     int
+}
+
+// BaseDelayCode returns the source code of the base delay of the rate limiter.
+func (limiter *RateLimiter) BaseDelayCode() string {
+	return durationCode(limiter.BaseDelay)
+}
+
+// MaxDelayCode returns the source code of the max delay of the rate limiter.
+func (limiter *RateLimiter) MaxDelayCode() string {
+	return durationCode(limiter.MaxDelay)
+}
+
+// QPSCode returns the source code of the queries per second of the rate limiter.
+func (limiter *RateLimiter) QPSCode() string {
+	return strconv.FormatFloat(limiter.QPS, 'f', -1, 64)
+}
+
+// processControllerOptions sets the options of the controller of the workload from the
+// workload config.  The controller reconciles a single workload at a time with the default
+// rate limiter of controller-runtime unless the workload config sets other options.
+func (ws *WorkloadSpec) processControllerOptions() error {
+	ws.ControllerOptions = &ControllerOptions{}
+
+	if ws.Controller == nil {
+		return nil
+	}
+
+	if ws.Controller.MaxConcurrentReconciles < 0 {
+		return fmt.Errorf("%w; maxConcurrentReconciles must not be negative", ErrInvalidControllerOptions)
+	}
+
+	ws.ControllerOptions.MaxConcurrentReconciles = ws.Controller.MaxConcurrentReconciles
+
+	if ws.Controller.RateLimiter == nil {
+		return nil
+	}
+
+	limiter, err := ws.Controller.RateLimiter.toRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	ws.ControllerOptions.RateLimiter = limiter
+
+	return nil
+}
+
+// toRateLimiter returns the rate limiter of a spec after checking that its values are valid.
+// The values which are not set default to those of the default rate limiter of controller-runtime.
+func (spec *RateLimiterSpec) toRateLimiter() (*RateLimiter, error) {
+	limiter := &RateLimiter{
+		BaseDelay: defaultRateLimiterBaseDelay,
+		MaxDelay:  defaultRateLimiterMaxDelay,
+		QPS:       defaultRateLimiterQPS,
+		Burst:     defaultRateLimiterBurst,
+	}
+
+	var err error
+
+	if spec.BaseDelay != "" {
+		if limiter.BaseDelay, err = time.ParseDuration(spec.BaseDelay); err != nil || limiter.BaseDelay <= 0 {
+			return nil, fmt.Errorf("%w; baseDelay %q of rate limiter must be a positive duration",
+				ErrInvalidControllerOptions, spec.BaseDelay)
+		}
+	}
+
+	if spec.MaxDelay != "" {
+		if limiter.MaxDelay, err = time.ParseDuration(spec.MaxDelay); err != nil {
+			return nil, fmt.Errorf("%w; maxDelay %q of rate limiter must be a duration",
+				ErrInvalidControllerOptions, spec.MaxDelay)
+		}
+	}
+
+	if limiter.MaxDelay < limiter.BaseDelay {
+		return nil, fmt.Errorf("%w; maxDelay of rate limiter must be at least its baseDelay", ErrInvalidControllerOptions)
+	}
+
+	if spec.QPS < 0 || spec.Burst < 0 {
+		return nil, fmt.Errorf("%w; qps and burst of rate limiter must not be negative", ErrInvalidControllerOptions)
+	}
+
+	if spec.QPS > 0 {
+		limiter.QPS = spec.QPS
+	}
+
+	if spec.Burst > 0 {
+		limiter.Burst = spec.Burst
+	}
+
+	return limiter, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadSpec_processControllerOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		controller *ControllerSpec
+		want       *ControllerOptions
+		wantErr    bool
+	}{
+		{
+			name:       "no controller config",
+			controller: nil,
+			want:       &ControllerOptions{},
+		},
+		{
+			name:       "max concurrent reconciles only",
+			controller: &ControllerSpec{MaxConcurrentReconciles: 4},
+			want:       &ControllerOptions{MaxConcurrentReconciles: 4},
+		},
+		{
+			name: "rate limiter with defaults",
+			controller: &ControllerSpec{
+				MaxConcurrentReconciles: 2,
+				RateLimiter:             &RateLimiterSpec{MaxDelay: "5m", QPS: 2.5},
+			},
+			want: &ControllerOptions{
+				MaxConcurrentReconciles: 2,
+				RateLimiter: &RateLimiter{
+					BaseDelay: defaultRateLimiterBaseDelay,
+					MaxDelay:  5 * time.Minute,
+					QPS:       2.5,
+					Burst:     defaultRateLimiterBurst,
+				},
+			},
+		},
+		{
+			name: "full rate limiter",
+			controller: &ControllerSpec{
+				RateLimiter: &RateLimiterSpec{BaseDelay: "1s", MaxDelay: "1m", QPS: 50, Burst: 200},
+			},
+			want: &ControllerOptions{
+				RateLimiter: &RateLimiter{BaseDelay: time.Second, MaxDelay: time.Minute, QPS: 50, Burst: 200},
+			},
+		},
+		{
+			name:       "negative max concurrent reconciles",
+			controller: &ControllerSpec{MaxConcurrentReconciles: -1},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid base delay",
+			controller: &ControllerSpec{RateLimiter: &RateLimiterSpec{BaseDelay: "soon"}},
+			wantErr:    true,
+		},
+		{
+			name:       "max delay less than base delay",
+			controller: &ControllerSpec{RateLimiter: &RateLimiterSpec{BaseDelay: "1m", MaxDelay: "10s"}},
+			wantErr:    true,
+		},
+		{
+			name:       "negative burst",
+			controller: &ControllerSpec{RateLimiter: &RateLimiterSpec{Burst: -1}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{Controller: tt.controller}
+
+			err := ws.processControllerOptions()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidControllerOptions)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ws.ControllerOptions)
+		})
+	}
+}
+
+func TestRateLimiter_QPSCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		qps  float64
+		want string
+	}{
+		{qps: 10, want: "10"},
+		{qps: 2.5, want: "2.5"},
+		{qps: 0.1, want: "0.1"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := &RateLimiter{QPS: tt.qps}
+			assert.Equal(t, tt.want, limiter.QPSCode())
+		})
+	}
+}
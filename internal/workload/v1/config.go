@@ -160,6 +160,10 @@ func parseConfig(workloadConfig string) (map[WorkloadKind][]WorkloadIdentifier,
 
 	defer CloseFile(file)
 
+	return decodeConfig(workloadConfig, file)
+}
+
+func decodeConfig(workloadConfig string, file io.Reader) (map[WorkloadKind][]WorkloadIdentifier, error) {
 	var kindReader bytes.Buffer
 	reader := io.TeeReader(file, &kindReader)
 
@@ -191,11 +195,17 @@ func parseConfig(workloadConfig string) (map[WorkloadKind][]WorkloadIdentifier,
 
 		workloadMap[workloadID.Name] = true
 
-		workload, err := decodeKind(workloadID.Kind, kindDecoder)
+		workload, err := decodeVersion(workloadID.APIVersion, workloadID.Kind, kindDecoder)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read file %s: %w", workloadConfig, err)
 		}
 
+		// the extends field of a component of a collection is resolved before it is decoded, so
+		// that it is only left on a component config which is not part of a collection
+		if component, ok := workload.(*ComponentWorkload); ok && component.Spec.Extends != "" {
+			return nil, fmt.Errorf("%w; %s", ErrExtendsNoCollection, workloadConfig)
+		}
+
 		workloads[workload.GetWorkloadKind()] = append(workloads[workload.GetWorkloadKind()], workload)
 
 		if collection, ok := workload.(*WorkloadCollection); ok {
@@ -225,7 +235,14 @@ func parseCollectionComponents(workload *WorkloadCollection, workloadConfig stri
 	for _, componentFile := range workload.Spec.ComponentFiles {
 		componentPath := filepath.Join(filepath.Dir(workloadConfig), componentFile)
 
-		w, err := parseConfig(componentPath)
+		// merge any extended configs and the collection defaults into the component
+		// config prior to decoding and validating it
+		content, err := resolveComponentConfig(componentPath, workload.Spec.ComponentDefaults)
+		if err != nil {
+			return nil, err
+		}
+
+		w, err := decodeConfig(componentPath, bytes.NewReader(content))
 		if err != nil {
 			return nil, err
 		}
@@ -241,25 +258,6 @@ func parseCollectionComponents(workload *WorkloadCollection, workloadConfig stri
 	return workloads, nil
 }
 
-func decodeKind(kind WorkloadKind, dc *yaml.Decoder) (WorkloadIdentifier, error) {
-	switch kind {
-	case WorkloadKindStandalone:
-		return decodeStandalone(dc)
-	case WorkloadKindComponent:
-		return decodeComponent(dc)
-	case WorkloadKindCollection:
-		return decodeCollection(dc)
-	default:
-		return nil, fmt.Errorf(
-			"%w - valid kinds: %s, %s, %s,",
-			ErrInvalidKind,
-			WorkloadKindStandalone,
-			WorkloadKindCollection,
-			WorkloadKindComponent,
-		)
-	}
-}
-
 func handleDependencies(components *[]*ComponentWorkload) error {
 	c := *components
 	// get a list of existing component names in the config
@@ -0,0 +1,75 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnsupportedConfigVersion = errors.New("unsupported workload config apiVersion")
+
+// Workload config versions.  Configs written before the apiVersion field was
+// introduced are treated as the legacy version and may be upgraded to the latest
+// version with the migrate-config command.
+const (
+	WorkloadConfigGroup         = "workloads.operatorbuilder.io"
+	WorkloadConfigVersionLegacy = ""
+	WorkloadConfigVersionV1     = WorkloadConfigGroup + "/v1"
+
+	WorkloadConfigLatestVersion = WorkloadConfigVersionV1
+)
+
+// versionedConfig is a workload config document of a specific config version.  Each
+// config version has its own decode types, which are converted into the internal
+// representation of a workload once decoded.
+type versionedConfig interface {
+	convert() WorkloadIdentifier
+}
+
+// configVersion returns the decode type of a workload kind for a config version, or
+// nil if the kind is not supported by the config version.
+type configVersion func(kind WorkloadKind) versionedConfig
+
+// configVersionsMap returns the decode types associated with each supported
+// workload config version.
+func configVersionsMap() map[string]configVersion {
+	return map[string]configVersion{
+		WorkloadConfigVersionLegacy: legacyConfig,
+		WorkloadConfigVersionV1:     v1Config,
+	}
+}
+
+// decodeVersion decodes a workload config document into the decode type for the
+// requested config version and converts it into the internal workload type.
+func decodeVersion(version string, kind WorkloadKind, dc *yaml.Decoder) (WorkloadIdentifier, error) {
+	newConfig, ok := configVersionsMap()[version]
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w %q - valid versions: %s",
+			ErrUnsupportedConfigVersion,
+			version,
+			WorkloadConfigVersionV1,
+		)
+	}
+
+	config := newConfig(kind)
+	if config == nil {
+		return nil, fmt.Errorf(
+			"%w - valid kinds: %s, %s, %s,",
+			ErrInvalidKind,
+			WorkloadKindStandalone,
+			WorkloadKindCollection,
+			WorkloadKindComponent,
+		)
+	}
+
+	if err := dc.Decode(config); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return config.convert(), nil
+}
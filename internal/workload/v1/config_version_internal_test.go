@@ -0,0 +1,139 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_decodeVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		version  string
+		kind     WorkloadKind
+		input    string
+		expected WorkloadIdentifier
+		wantErr  error
+	}{
+		{
+			name:    "legacy standalone config is converted to the latest version",
+			version: WorkloadConfigVersionLegacy,
+			kind:    WorkloadKindStandalone,
+			input: `name: webstore
+kind: StandaloneWorkload
+spec:
+  api:
+    domain: acme.com
+`,
+			expected: &StandaloneWorkload{
+				WorkloadShared: WorkloadShared{
+					APIVersion: WorkloadConfigVersionV1,
+					Name:       "webstore",
+					Kind:       WorkloadKindStandalone,
+				},
+				Spec: StandaloneWorkloadSpec{API: WorkloadAPISpec{Domain: "acme.com"}},
+			},
+		},
+		{
+			name:    "legacy collection config is converted to the latest version",
+			version: WorkloadConfigVersionLegacy,
+			kind:    WorkloadKindCollection,
+			input: `name: platform
+kind: WorkloadCollection
+spec:
+  componentFiles:
+    - component.yaml
+`,
+			expected: &WorkloadCollection{
+				WorkloadShared: WorkloadShared{
+					APIVersion: WorkloadConfigVersionV1,
+					Name:       "platform",
+					Kind:       WorkloadKindCollection,
+				},
+				Spec: WorkloadCollectionSpec{ComponentFiles: []string{"component.yaml"}},
+			},
+		},
+		{
+			name:    "v1 component config is converted",
+			version: WorkloadConfigVersionV1,
+			kind:    WorkloadKindComponent,
+			input: `apiVersion: workloads.operatorbuilder.io/v1
+name: ingress
+kind: ComponentWorkload
+spec:
+  dependencies:
+    - cert-manager
+`,
+			expected: &ComponentWorkload{
+				WorkloadShared: WorkloadShared{
+					APIVersion: WorkloadConfigVersionV1,
+					Name:       "ingress",
+					Kind:       WorkloadKindComponent,
+				},
+				Spec: ComponentWorkloadSpec{Dependencies: []string{"cert-manager"}},
+			},
+		},
+		{
+			name:    "unsupported config version results in an error",
+			version: "workloads.operatorbuilder.io/v99",
+			kind:    WorkloadKindStandalone,
+			input: `apiVersion: workloads.operatorbuilder.io/v99
+name: webstore
+kind: StandaloneWorkload
+`,
+			wantErr: ErrUnsupportedConfigVersion,
+		},
+		{
+			name:    "unsupported workload kind results in an error",
+			version: WorkloadConfigVersionV1,
+			kind:    WorkloadKindUnknown,
+			input: `apiVersion: workloads.operatorbuilder.io/v1
+name: webstore
+`,
+			wantErr: ErrInvalidKind,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			decoder := yaml.NewDecoder(bytes.NewBufferString(tt.input))
+			decoder.KnownFields(true)
+
+			got, err := decodeVersion(tt.version, tt.kind, decoder)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_decodeVersion_unknownField(t *testing.T) {
+	t.Parallel()
+
+	// the legacy config has no apiVersion field, so a document which is decoded as a
+	// legacy config must not carry one
+	decoder := yaml.NewDecoder(bytes.NewBufferString(`apiVersion: workloads.operatorbuilder.io/v1
+name: webstore
+kind: StandaloneWorkload
+`))
+	decoder.KnownFields(true)
+
+	_, err := decodeVersion(WorkloadConfigVersionLegacy, WorkloadKindStandalone, decoder)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+// The decode types of workload configs which were written prior to versioning.  A
+// legacy config is converted into the v1 config, which is then converted into the
+// internal workload types, so that the legacy config is stamped with the v1 version.
+
+// standaloneWorkloadLegacy is a legacy standalone workload config.
+type standaloneWorkloadLegacy struct {
+	Name string                 `yaml:"name"`
+	Kind WorkloadKind           `yaml:"kind"`
+	Spec StandaloneWorkloadSpec `yaml:"spec"`
+}
+
+// workloadCollectionLegacy is a legacy workload collection config.
+type workloadCollectionLegacy struct {
+	Name string                 `yaml:"name"`
+	Kind WorkloadKind           `yaml:"kind"`
+	Spec WorkloadCollectionSpec `yaml:"spec"`
+}
+
+// componentWorkloadLegacy is a legacy component workload config.
+type componentWorkloadLegacy struct {
+	Name string                `yaml:"name"`
+	Kind WorkloadKind          `yaml:"kind"`
+	Spec ComponentWorkloadSpec `yaml:"spec"`
+}
+
+// legacyConfig returns the legacy decode type of a workload kind.
+func legacyConfig(kind WorkloadKind) versionedConfig {
+	switch kind {
+	case WorkloadKindStandalone:
+		return &standaloneWorkloadLegacy{}
+	case WorkloadKindCollection:
+		return &workloadCollectionLegacy{}
+	case WorkloadKindComponent:
+		return &componentWorkloadLegacy{}
+	default:
+		return nil
+	}
+}
+
+func (w *standaloneWorkloadLegacy) convert() WorkloadIdentifier {
+	v1 := &standaloneWorkloadV1{
+		APIVersion: WorkloadConfigVersionV1,
+		Name:       w.Name,
+		Kind:       w.Kind,
+		Spec:       w.Spec,
+	}
+
+	return v1.convert()
+}
+
+func (w *workloadCollectionLegacy) convert() WorkloadIdentifier {
+	v1 := &workloadCollectionV1{
+		APIVersion: WorkloadConfigVersionV1,
+		Name:       w.Name,
+		Kind:       w.Kind,
+		Spec:       w.Spec,
+	}
+
+	return v1.convert()
+}
+
+func (w *componentWorkloadLegacy) convert() WorkloadIdentifier {
+	v1 := &componentWorkloadV1{
+		APIVersion: WorkloadConfigVersionV1,
+		Name:       w.Name,
+		Kind:       w.Kind,
+		Spec:       w.Spec,
+	}
+
+	return v1.convert()
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+// The decode types of the v1 workload config version.  The specs of the v1 config
+// are identical to the internal specs of the workloads.  When a later config
+// version changes a spec, the v1 spec is copied here and converted into the
+// changed internal spec by the convert functions below.
+
+// standaloneWorkloadV1 is a v1 standalone workload config.
+type standaloneWorkloadV1 struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Name       string                 `yaml:"name"`
+	Kind       WorkloadKind           `yaml:"kind"`
+	Spec       StandaloneWorkloadSpec `yaml:"spec"`
+}
+
+// workloadCollectionV1 is a v1 workload collection config.
+type workloadCollectionV1 struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Name       string                 `yaml:"name"`
+	Kind       WorkloadKind           `yaml:"kind"`
+	Spec       WorkloadCollectionSpec `yaml:"spec"`
+}
+
+// componentWorkloadV1 is a v1 component workload config.
+type componentWorkloadV1 struct {
+	APIVersion string                `yaml:"apiVersion"`
+	Name       string                `yaml:"name"`
+	Kind       WorkloadKind          `yaml:"kind"`
+	Spec       ComponentWorkloadSpec `yaml:"spec"`
+}
+
+// v1Config returns the v1 decode type of a workload kind.
+func v1Config(kind WorkloadKind) versionedConfig {
+	switch kind {
+	case WorkloadKindStandalone:
+		return &standaloneWorkloadV1{}
+	case WorkloadKindCollection:
+		return &workloadCollectionV1{}
+	case WorkloadKindComponent:
+		return &componentWorkloadV1{}
+	default:
+		return nil
+	}
+}
+
+func (w *standaloneWorkloadV1) convert() WorkloadIdentifier {
+	return &StandaloneWorkload{
+		WorkloadShared: WorkloadShared{
+			APIVersion: w.APIVersion,
+			Name:       w.Name,
+			Kind:       w.Kind,
+		},
+		Spec: w.Spec,
+	}
+}
+
+func (w *workloadCollectionV1) convert() WorkloadIdentifier {
+	return &WorkloadCollection{
+		WorkloadShared: WorkloadShared{
+			APIVersion: w.APIVersion,
+			Name:       w.Name,
+			Kind:       w.Kind,
+		},
+		Spec: w.Spec,
+	}
+}
+
+func (w *componentWorkloadV1) convert() WorkloadIdentifier {
+	return &ComponentWorkload{
+		WorkloadShared: WorkloadShared{
+			APIVersion: w.APIVersion,
+			Name:       w.Name,
+			Kind:       w.Kind,
+		},
+		Spec: w.Spec,
+	}
+}
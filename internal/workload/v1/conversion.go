@@ -0,0 +1,185 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+	"strings"
+)
+
+var ErrMissingAPISpec = errors.New("unable to find api spec type")
+
+// APIConversion describes the conversion of the spec of a kind between a spoke version
+// and the hub version which is stored in the cluster.
+type APIConversion struct {
+	Kind         string
+	SpokeVersion string
+	HubVersion   string
+	Spoke        *APIFields
+	Hub          *APIFields
+}
+
+// ParseAPISpecFields rebuilds the api field tree for a kind from the source of its
+// api types file.  It is used to recover the fields of a previously scaffolded version
+// as the workload config only describes the version which is being scaffolded.
+func ParseAPISpecFields(src []byte, kind string) (*APIFields, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse api types for kind %s, %w", kind, err)
+	}
+
+	structs := make(map[string]*ast.StructType)
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if typeSpec, ok := node.(*ast.TypeSpec); ok {
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				structs[typeSpec.Name.Name] = structType
+			}
+		}
+
+		return true
+	})
+
+	spec, ok := structs[kind+"Spec"]
+	if !ok {
+		return nil, fmt.Errorf("%w %sSpec", ErrMissingAPISpec, kind)
+	}
+
+	api := &APIFields{
+		Name:         "Spec",
+		StructName:   "Spec",
+		manifestName: "spec",
+		Type:         FieldStruct,
+	}
+
+	api.Children = parseAPIStruct(structs, spec, kind, map[string]bool{kind + "Spec": true})
+
+	return api, nil
+}
+
+func parseAPIStruct(structs map[string]*ast.StructType, spec *ast.StructType, kind string, seen map[string]bool) []*APIFields {
+	children := []*APIFields{}
+
+	for _, field := range spec.Fields.List {
+		for _, name := range field.Names {
+			child := &APIFields{
+				Name:         name.Name,
+				manifestName: name.Name,
+				Type:         FieldUnknownType,
+				Comments:     []string{},
+				Markers:      []string{},
+			}
+
+			if field.Tag != nil {
+				child.Tags = field.Tag.Value
+
+				tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+				if jsonName := strings.Split(tag, ",")[0]; jsonName != "" {
+					child.manifestName = jsonName
+				}
+			}
+
+			ident, ok := field.Type.(*ast.Ident)
+			if !ok {
+				children = append(children, child)
+
+				continue
+			}
+
+			if nested, ok := structs[ident.Name]; ok && !seen[ident.Name] {
+				seen[ident.Name] = true
+
+				child.Type = FieldStruct
+				child.StructName = strings.TrimPrefix(ident.Name, kind)
+				child.Children = parseAPIStruct(structs, nested, kind, seen)
+
+				delete(seen, ident.Name)
+			} else {
+				_ = child.Type.UnmarshalMarkerArg(ident.Name)
+			}
+
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// GenerateConvertTo generates the statements which copy the spec of the spoke version,
+// named src, into the spec of the hub version, named dst.
+func (c *APIConversion) GenerateConvertTo() string {
+	var buf bytes.Buffer
+
+	generateConversion(&buf, c.Hub, c.Spoke, "dst.Spec", "src.Spec", c.HubVersion, c.SpokeVersion)
+
+	return buf.String()
+}
+
+// GenerateConvertFrom generates the statements which copy the spec of the hub version,
+// named src, into the spec of the spoke version, named dst.
+func (c *APIConversion) GenerateConvertFrom() string {
+	var buf bytes.Buffer
+
+	generateConversion(&buf, c.Spoke, c.Hub, "dst.Spec", "src.Spec", c.SpokeVersion, c.HubVersion)
+
+	return buf.String()
+}
+
+// generateConversion writes an assignment for each field which is identically named
+// and typed in both trees and a TODO for each field which has been added, removed or
+// changed between the versions.
+func generateConversion(b io.StringWriter, dst, src *APIFields, dstPath, srcPath, dstVersion, srcVersion string) {
+	for _, dstChild := range dst.Children {
+		dstField := fmt.Sprintf("%s.%s", dstPath, dstChild.Name)
+
+		srcChild := src.getChild(dstChild.Name)
+		if srcChild == nil {
+			mustWrite(b.WriteString(fmt.Sprintf(
+				"// TODO(user): set %s which does not exist in %s.\n", dstField, srcVersion,
+			)))
+
+			continue
+		}
+
+		srcField := fmt.Sprintf("%s.%s", srcPath, srcChild.Name)
+
+		switch {
+		case dstChild.Type == FieldStruct && srcChild.Type == FieldStruct:
+			generateConversion(b, dstChild, srcChild, dstField, srcField, dstVersion, srcVersion)
+		case dstChild.Type == srcChild.Type && dstChild.Type != FieldUnknownType:
+			mustWrite(b.WriteString(fmt.Sprintf("%s = %s\n", dstField, srcField)))
+		default:
+			mustWrite(b.WriteString(fmt.Sprintf(
+				"// TODO(user): convert %s to %s as the field was changed between %s and %s.\n",
+				srcField, dstField, srcVersion, dstVersion,
+			)))
+		}
+	}
+
+	for _, srcChild := range src.Children {
+		if dst.getChild(srcChild.Name) == nil {
+			mustWrite(b.WriteString(fmt.Sprintf(
+				"// TODO(user): %s.%s does not exist in %s and is lost by this conversion.\n",
+				srcPath, srcChild.Name, dstVersion,
+			)))
+		}
+	}
+}
+
+func (api *APIFields) getChild(name string) *APIFields {
+	for _, child := range api.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const conversionTestTypes = `package v1alpha1
+
+type WebAppSpec struct {
+	//+operator-builder:user:begin:spec
+	Debug bool ` + "`" + `json:"debug,omitempty"` + "`" + `
+	//+operator-builder:user:end:spec
+
+	Replicas int ` + "`" + `json:"replicas"` + "`" + `
+
+	Image WebAppSpecImage ` + "`" + `json:"image"` + "`" + `
+
+	Labels map[string]string ` + "`" + `json:"labels"` + "`" + `
+}
+
+type WebAppSpecImage struct {
+	Name string ` + "`" + `json:"name"` + "`" + `
+
+	Tag string ` + "`" + `json:"tag"` + "`" + `
+}
+`
+
+func TestParseAPISpecFields(t *testing.T) {
+	t.Parallel()
+
+	api, err := ParseAPISpecFields([]byte(conversionTestTypes), "WebApp")
+	require.NoError(t, err)
+
+	assert.Equal(t, FieldStruct, api.Type)
+	require.Len(t, api.Children, 4)
+
+	assert.Equal(t, "Debug", api.Children[0].Name)
+	assert.Equal(t, "debug", api.Children[0].manifestName)
+	assert.Equal(t, FieldBool, api.Children[0].Type)
+
+	assert.Equal(t, FieldInt, api.Children[1].Type)
+
+	image := api.Children[2]
+	assert.Equal(t, FieldStruct, image.Type)
+	assert.Equal(t, "SpecImage", image.StructName)
+	require.Len(t, image.Children, 2)
+	assert.Equal(t, "tag", image.Children[1].manifestName)
+	assert.Equal(t, FieldString, image.Children[1].Type)
+
+	assert.Equal(t, FieldUnknownType, api.Children[3].Type)
+
+	_, err = ParseAPISpecFields([]byte(conversionTestTypes), "Other")
+	assert.ErrorIs(t, err, ErrMissingAPISpec)
+}
+
+func TestAPIConversion_Generate(t *testing.T) {
+	t.Parallel()
+
+	spoke := &APIFields{}
+	require.NoError(t, spoke.AddField("replicas", FieldInt, nil, 1, false))
+	require.NoError(t, spoke.AddField("image.name", FieldString, nil, "nginx", false))
+	require.NoError(t, spoke.AddField("image.tag", FieldString, nil, "latest", false))
+	require.NoError(t, spoke.AddField("port", FieldInt, nil, 80, false))
+
+	hub := &APIFields{}
+	require.NoError(t, hub.AddField("replicas", FieldInt, nil, 1, false))
+	require.NoError(t, hub.AddField("image.name", FieldString, nil, "nginx", false))
+	require.NoError(t, hub.AddField("image.tag", FieldInt, nil, 1, false))
+	require.NoError(t, hub.AddField("host", FieldString, nil, "example.com", false))
+
+	conversion := &APIConversion{
+		Kind:         "WebApp",
+		SpokeVersion: "v1alpha1",
+		HubVersion:   "v1beta1",
+		Spoke:        spoke,
+		Hub:          hub,
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "convert to hub",
+			got:  conversion.GenerateConvertTo(),
+			want: `dst.Spec.Replicas = src.Spec.Replicas
+dst.Spec.Image.Name = src.Spec.Image.Name
+// TODO(user): convert src.Spec.Image.Tag to dst.Spec.Image.Tag as the field was changed between v1alpha1 and v1beta1.
+// TODO(user): set dst.Spec.Host which does not exist in v1alpha1.
+// TODO(user): src.Spec.Port does not exist in v1beta1 and is lost by this conversion.
+`,
+		},
+		{
+			name: "convert from hub",
+			got:  conversion.GenerateConvertFrom(),
+			want: `dst.Spec.Replicas = src.Spec.Replicas
+dst.Spec.Image.Name = src.Spec.Image.Name
+// TODO(user): convert src.Spec.Image.Tag to dst.Spec.Image.Tag as the field was changed between v1beta1 and v1alpha1.
+// TODO(user): set dst.Spec.Port which does not exist in v1beta1.
+// TODO(user): src.Spec.Host does not exist in v1alpha1 and is lost by this conversion.
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidDeletePolicy   = errors.New("delete marker policy must be one of delete, orphan or retain")
+	ErrInvalidDeleteWave     = errors.New("delete marker wave must not be negative")
+	ErrDuplicateDeleteMarker = errors.New("delete marker is used more than once for a child resource")
+)
+
+const (
+	DeletePolicyDelete = "delete"
+	DeletePolicyOrphan = "orphan"
+	DeletePolicyRetain = "retain"
+)
+
+// DeleteWave is a set of child resources which are torn down together when the workload is
+// deleted.  The child resources of a wave are only torn down once those of each higher wave
+// are gone.
+type DeleteWave struct {
+	Wave      int
+	Resources []*DeleteResource
+}
+
+// DeleteResource is a child resource which is torn down with the given policy.
+type DeleteResource struct {
+	Policy     string
+	CreateFunc string
+}
+
+// defaultDeleteWave returns the wave of a child resource without a delete marker.  Custom
+// resources are deleted first and namespaces and custom resource definitions last, so that
+// child resources are deleted before the resources they depend upon.
+func defaultDeleteWave(child *ChildResource) int {
+	switch {
+	case child.Group == coreRBACGroup && child.Kind == "Namespace",
+		child.Group == "apiextensions.k8s.io" && child.Kind == "CustomResourceDefinition":
+		return 0
+	case child.Group == coreRBACGroup && (child.Kind == "ServiceAccount" || child.Kind == "Secret" ||
+		child.Kind == "ConfigMap" || child.Kind == "PersistentVolumeClaim"),
+		child.Group == "rbac.authorization.k8s.io":
+		return 1
+	case isCustomResourceGroup(child.Group):
+		return 3
+	}
+
+	return 2
+}
+
+// isCustomResourceGroup returns whether a group is not one of the groups built into Kubernetes.
+func isCustomResourceGroup(group string) bool {
+	switch group {
+	case coreRBACGroup, "apps", "batch", "autoscaling", "policy", "extensions":
+		return false
+	}
+
+	return !strings.HasSuffix(group, ".k8s.io")
+}
+
+// processDeleteMarkers adds a child resource to its delete wave, as set by the delete marker
+// of its manifest or by its kind, and returns the manifest with the marker transformed.
+func (ws *WorkloadSpec) processDeleteMarkers(manifest string, child *ChildResource) (string, error) {
+	nodes, markerResults, err := inspectMarkersForYAML([]byte(manifest), DeleteMarkerType)
+	if err != nil {
+		return "", err
+	}
+
+	var dm *DeleteMarker
+
+	for _, markerResult := range markerResults {
+		marker, ok := markerResult.Object.(DeleteMarker)
+		if !ok {
+			continue
+		}
+
+		if dm != nil {
+			return "", fmt.Errorf("%w; %s for marker %s", ErrDuplicateDeleteMarker, child.Name, marker)
+		}
+
+		dm = &marker
+	}
+
+	if err := ws.addDeleteResource(dm, child); err != nil {
+		return "", err
+	}
+
+	if len(markerResults) == 0 {
+		return manifest, nil
+	}
+
+	return marshalManifest(nodes, child)
+}
+
+// addDeleteResource adds a child resource to its delete wave after checking that the policy
+// and wave of its delete marker, if any, are valid.  Child resources which are retained are
+// not torn down and are not added to a wave.
+func (ws *WorkloadSpec) addDeleteResource(dm *DeleteMarker, child *ChildResource) error {
+	policy, wave := DeletePolicyDelete, defaultDeleteWave(child)
+
+	if dm != nil {
+		if dm.Policy != nil {
+			policy = *dm.Policy
+		}
+
+		if dm.Wave != nil {
+			wave = *dm.Wave
+		}
+	}
+
+	switch policy {
+	case DeletePolicyDelete, DeletePolicyOrphan:
+	case DeletePolicyRetain:
+		return nil
+	default:
+		return fmt.Errorf("%w; %s for marker %s", ErrInvalidDeletePolicy, policy, dm)
+	}
+
+	if wave < 0 {
+		return fmt.Errorf("%w; %d for marker %s", ErrInvalidDeleteWave, wave, dm)
+	}
+
+	resource := &DeleteResource{
+		Policy:     policy,
+		CreateFunc: "Create" + child.UniqueName,
+	}
+
+	// keep the waves in the order in which they are torn down, highest first
+	for i, existing := range ws.DeleteWaves {
+		if existing.Wave == wave {
+			existing.Resources = append(existing.Resources, resource)
+
+			return nil
+		}
+
+		if existing.Wave < wave {
+			ws.DeleteWaves = append(ws.DeleteWaves[:i], append([]*DeleteWave{{Wave: wave}}, ws.DeleteWaves[i:]...)...)
+			ws.DeleteWaves[i].Resources = []*DeleteResource{resource}
+
+			return nil
+		}
+	}
+
+	ws.DeleteWaves = append(ws.DeleteWaves, &DeleteWave{Wave: wave, Resources: []*DeleteResource{resource}})
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadSpec_addDeleteResource(t *testing.T) {
+	t.Parallel()
+
+	orphan, retain, unknown := DeletePolicyOrphan, DeletePolicyRetain, "keep"
+	first, negative := 5, -1
+
+	child := &ChildResource{Name: "webstore-db", Group: "acme.com", Kind: "Cluster", UniqueName: "ClusterWebstoreDb"}
+
+	tests := []struct {
+		name    string
+		marker  *DeleteMarker
+		want    []*DeleteWave
+		wantErr error
+	}{
+		{
+			name:   "default policy and wave",
+			marker: nil,
+			want: []*DeleteWave{
+				{Wave: 3, Resources: []*DeleteResource{{Policy: DeletePolicyDelete, CreateFunc: "CreateClusterWebstoreDb"}}},
+				{Wave: 2, Resources: []*DeleteResource{{Policy: DeletePolicyDelete, CreateFunc: "CreateDeploymentWebstoreDeploy"}}},
+			},
+		},
+		{
+			name:   "orphan in the first wave",
+			marker: &DeleteMarker{Policy: &orphan, Wave: &first},
+			want: []*DeleteWave{
+				{Wave: 5, Resources: []*DeleteResource{{Policy: DeletePolicyOrphan, CreateFunc: "CreateClusterWebstoreDb"}}},
+				{Wave: 2, Resources: []*DeleteResource{{Policy: DeletePolicyDelete, CreateFunc: "CreateDeploymentWebstoreDeploy"}}},
+			},
+		},
+		{
+			name:   "retain",
+			marker: &DeleteMarker{Policy: &retain},
+			want: []*DeleteWave{
+				{Wave: 2, Resources: []*DeleteResource{{Policy: DeletePolicyDelete, CreateFunc: "CreateDeploymentWebstoreDeploy"}}},
+			},
+		},
+		{
+			name:    "invalid policy",
+			marker:  &DeleteMarker{Policy: &unknown},
+			wantErr: ErrInvalidDeletePolicy,
+		},
+		{
+			name:    "negative wave",
+			marker:  &DeleteMarker{Wave: &negative},
+			wantErr: ErrInvalidDeleteWave,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{}
+			require.NoError(t, ws.addDeleteResource(nil, &ChildResource{
+				Name:       "webstore-deploy",
+				Group:      "apps",
+				Kind:       "Deployment",
+				UniqueName: "DeploymentWebstoreDeploy",
+			}))
+
+			err := ws.addDeleteResource(tt.marker, child)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ws.DeleteWaves)
+		})
+	}
+}
+
+func Test_defaultDeleteWave(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		group string
+		kind  string
+		want  int
+	}{
+		{group: coreRBACGroup, kind: "Namespace", want: 0},
+		{group: "apiextensions.k8s.io", kind: "CustomResourceDefinition", want: 0},
+		{group: coreRBACGroup, kind: "ConfigMap", want: 1},
+		{group: "rbac.authorization.k8s.io", kind: "ClusterRole", want: 1},
+		{group: "apps", kind: "Deployment", want: 2},
+		{group: "networking.k8s.io", kind: "Ingress", want: 2},
+		{group: "cert-manager.io", kind: "Certificate", want: 3},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.kind, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, defaultDeleteWave(&ChildResource{Group: tt.group, Kind: tt.kind}))
+		})
+	}
+}
+
+func TestWorkloadSpec_processDeleteMarkers(t *testing.T) {
+	t.Parallel()
+
+	manifest := `
+# +operator-builder:delete:policy=orphan
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: webstore-data
+`
+
+	ws := &WorkloadSpec{}
+
+	got, err := ws.processDeleteMarkers(manifest, &ChildResource{
+		Name:       "webstore-data",
+		Group:      coreRBACGroup,
+		Kind:       "PersistentVolumeClaim",
+		UniqueName: "PersistentVolumeClaimWebstoreData",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "# deleted with policy: orphan")
+	assert.NotContains(t, got, "+operator-builder:delete")
+	assert.Equal(t, []*DeleteWave{
+		{Wave: 1, Resources: []*DeleteResource{{Policy: DeletePolicyOrphan, CreateFunc: "CreatePersistentVolumeClaimWebstoreData"}}},
+	}, ws.DeleteWaves)
+
+	_, err = ws.processDeleteMarkers(`
+# +operator-builder:delete:policy=orphan
+# +operator-builder:delete:wave=1
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: webstore-config
+`, &ChildResource{Name: "webstore-config", Group: coreRBACGroup, Kind: "ConfigMap"})
+	assert.ErrorIs(t, err, ErrDuplicateDeleteMarker)
+}
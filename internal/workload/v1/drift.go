@@ -0,0 +1,87 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var ErrInvalidDriftPath = errors.New("drift marker ignore must be a field path such as .spec.replicas")
+
+// driftPathRegex matches a field path as reported by server-side apply, which is a sequence
+// of field names, each prefixed with a dot, and list selectors such as [name="nginx"].
+var driftPathRegex = regexp.MustCompile(`^(\.[^.\[\]]+|\[[^\]]+\])+$`)
+
+// DriftRule sets how a child resource is applied when its fields are changed by other field
+// managers.  Changed fields are reverted unless Revert is false, in which case they are only
+// reported, and fields at the Ignore paths are neither reverted nor reported once the child
+// resource exists.
+type DriftRule struct {
+	CreateFunc string
+	Revert     bool
+	Ignore     []string
+}
+
+// processDriftMarkers adds the drift rule set by the drift markers of a manifest and returns
+// the manifest with the markers transformed.  A child resource may have multiple drift markers,
+// for example, one for each path which is ignored.
+func (ws *WorkloadSpec) processDriftMarkers(manifest string, child *ChildResource) (string, error) {
+	nodes, markerResults, err := inspectMarkersForYAML([]byte(manifest), DriftMarkerType)
+	if err != nil {
+		return "", err
+	}
+
+	if len(markerResults) == 0 {
+		return manifest, nil
+	}
+
+	markers := []*DriftMarker{}
+
+	for _, markerResult := range markerResults {
+		marker, ok := markerResult.Object.(DriftMarker)
+		if !ok {
+			continue
+		}
+
+		markers = append(markers, &marker)
+	}
+
+	if err := ws.addDriftRule(markers, child); err != nil {
+		return "", err
+	}
+
+	return marshalManifest(nodes, child)
+}
+
+// addDriftRule adds the drift rule of a child resource after checking that the paths of its
+// drift markers are valid.  Drift is only reported for the child resource when any of its
+// markers sets revert to false.
+func (ws *WorkloadSpec) addDriftRule(markers []*DriftMarker, child *ChildResource) error {
+	rule := &DriftRule{
+		CreateFunc: "Create" + child.UniqueName,
+		Revert:     true,
+	}
+
+	for _, dm := range markers {
+		if dm.Revert != nil && !*dm.Revert {
+			rule.Revert = false
+		}
+
+		if dm.Ignore == nil {
+			continue
+		}
+
+		if !driftPathRegex.MatchString(*dm.Ignore) {
+			return fmt.Errorf("%w; %s for marker %s", ErrInvalidDriftPath, *dm.Ignore, dm)
+		}
+
+		rule.Ignore = append(rule.Ignore, *dm.Ignore)
+	}
+
+	ws.DriftRules = append(ws.DriftRules, rule)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadSpec_addDriftRule(t *testing.T) {
+	t.Parallel()
+
+	revert, report := true, false
+	replicas, image, invalid := ".spec.replicas", `.spec.template.spec.containers[name="nginx"].image`, "spec.replicas"
+
+	child := &ChildResource{Name: "webstore-deploy", Group: "apps", Kind: "Deployment", UniqueName: "DeploymentWebstoreDeploy"}
+
+	tests := []struct {
+		name    string
+		markers []*DriftMarker
+		want    *DriftRule
+		wantErr error
+	}{
+		{
+			name:    "revert",
+			markers: []*DriftMarker{{Revert: &revert}},
+			want:    &DriftRule{CreateFunc: "CreateDeploymentWebstoreDeploy", Revert: true},
+		},
+		{
+			name:    "report",
+			markers: []*DriftMarker{{Revert: &report}},
+			want:    &DriftRule{CreateFunc: "CreateDeploymentWebstoreDeploy", Revert: false},
+		},
+		{
+			name:    "ignore multiple paths",
+			markers: []*DriftMarker{{Ignore: &replicas}, {Ignore: &image}},
+			want: &DriftRule{
+				CreateFunc: "CreateDeploymentWebstoreDeploy",
+				Revert:     true,
+				Ignore:     []string{replicas, image},
+			},
+		},
+		{
+			name:    "invalid path",
+			markers: []*DriftMarker{{Ignore: &invalid}},
+			wantErr: ErrInvalidDriftPath,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{}
+
+			err := ws.addDriftRule(tt.markers, child)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []*DriftRule{tt.want}, ws.DriftRules)
+		})
+	}
+}
+
+func TestWorkloadSpec_processDriftMarkers(t *testing.T) {
+	t.Parallel()
+
+	manifest := `
+# +operator-builder:drift:revert=false
+# +operator-builder:drift:ignore=".spec.replicas"
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webstore-deploy
+spec:
+  replicas: 2
+`
+
+	ws := &WorkloadSpec{}
+
+	got, err := ws.processDriftMarkers(manifest, &ChildResource{
+		Name:       "webstore-deploy",
+		Group:      "apps",
+		Kind:       "Deployment",
+		UniqueName: "DeploymentWebstoreDeploy",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "# drift reported")
+	assert.Contains(t, got, "# drift ignored at: .spec.replicas")
+	assert.NotContains(t, got, "+operator-builder:drift")
+	assert.Equal(t, []*DriftRule{
+		{CreateFunc: "CreateDeploymentWebstoreDeploy", Revert: false, Ignore: []string{".spec.replicas"}},
+	}, ws.DriftRules)
+
+	unmarked := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: webstore-config\n"
+
+	got, err = ws.processDriftMarkers(unmarked, &ChildResource{Name: "webstore-config", Group: coreRBACGroup, Kind: "ConfigMap"})
+	require.NoError(t, err)
+	assert.Equal(t, unmarked, got)
+	assert.Len(t, ws.DriftRules, 1)
+}
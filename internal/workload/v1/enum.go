@@ -0,0 +1,112 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidEnum = errors.New("field marker enum is invalid")
+	ErrEnumDefault = errors.New("field marker default is not one of the values of its enum")
+)
+
+// setEnum restricts the api field at path to the values of the enum of a field marker,
+// which are separated by semicolons, by adding the Enum validation marker to the field.
+func (api *APIFields) setEnum(path string, enum *string) error {
+	if enum == nil {
+		return nil
+	}
+
+	fields := api.findField(path)
+	if fields == nil {
+		return nil
+	}
+
+	field := fields[len(fields)-1]
+
+	values, err := parseEnum(*enum, field.Type)
+	if err != nil {
+		return fmt.Errorf("%w for api field %s", err, path)
+	}
+
+	if existing := field.enumValues(); existing != nil {
+		if strings.Join(existing, ";") != strings.Join(values, ";") {
+			return fmt.Errorf("%w for api field %s", ErrOverwriteExistingValue, path)
+		}
+
+		return nil
+	}
+
+	if value := field.defaultValue(); value != "" && !containsEnumValue(values, value, field.Type) {
+		return fmt.Errorf("%w; %s of api field %s must be one of %s", ErrEnumDefault, value, path, *enum)
+	}
+
+	// add the marker ahead of the description of the default of the field, if any
+	index := len(field.Markers)
+
+	for i, marker := range field.Markers {
+		if !strings.HasPrefix(marker, "+") {
+			index = i
+
+			break
+		}
+	}
+
+	marker := enumMarker + strings.Join(values, ";")
+	field.Markers = append(field.Markers[:index], append([]string{marker}, field.Markers[index:]...)...)
+
+	return nil
+}
+
+// parseEnum returns the values of an enum, which must be valid values of the type of the
+// field.  Enums are not supported for boolean fields.
+func parseEnum(enum string, fieldType FieldType) ([]string, error) {
+	values := []string{}
+
+	for _, value := range strings.Split(enum, ";") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		switch fieldType {
+		case FieldString:
+		case FieldInt:
+			if _, err := strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("%w; %s is not an int", ErrInvalidEnum, value)
+			}
+		default:
+			return nil, fmt.Errorf("%w; enum is not supported for type %s", ErrInvalidEnum, fieldType)
+		}
+
+		values = append(values, value)
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w; enum has no values", ErrInvalidEnum)
+	}
+
+	return values, nil
+}
+
+// containsEnumValue returns whether the values of an enum contain the default value of a
+// field, which is quoted for a string field.
+func containsEnumValue(values []string, value string, fieldType FieldType) bool {
+	if fieldType == FieldString {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
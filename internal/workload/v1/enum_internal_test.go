@@ -0,0 +1,146 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIFields_setEnum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		fieldType  FieldType
+		sample     interface{}
+		hasDefault bool
+		enums      []string
+		want       []string
+		wantErr    error
+	}{
+		{
+			name:      "string enum",
+			fieldType: FieldString,
+			sample:    "small",
+			enums:     []string{"small; medium;large"},
+			want:      []string{"+kubebuilder:validation:Enum=small;medium;large"},
+		},
+		{
+			name:       "string enum with default",
+			fieldType:  FieldString,
+			sample:     "medium",
+			hasDefault: true,
+			enums:      []string{"small;medium"},
+			want: []string{
+				`+kubebuilder:default="medium"`,
+				"+kubebuilder:validation:Optional",
+				"+kubebuilder:validation:Enum=small;medium",
+				`(Default: "medium")`,
+			},
+		},
+		{
+			name:      "int enum",
+			fieldType: FieldInt,
+			sample:    1,
+			enums:     []string{"1;3;5"},
+			want:      []string{"+kubebuilder:validation:Enum=1;3;5"},
+		},
+		{
+			name:      "same enum on multiple markers",
+			fieldType: FieldString,
+			sample:    "small",
+			enums:     []string{"small;large", "small;large"},
+			want:      []string{"+kubebuilder:validation:Enum=small;large"},
+		},
+		{
+			name:      "different enums on multiple markers",
+			fieldType: FieldString,
+			sample:    "small",
+			enums:     []string{"small;large", "small"},
+			wantErr:   ErrOverwriteExistingValue,
+		},
+		{
+			name:       "default not in enum",
+			fieldType:  FieldString,
+			sample:     "huge",
+			hasDefault: true,
+			enums:      []string{"small;large"},
+			wantErr:    ErrEnumDefault,
+		},
+		{
+			name:      "invalid int enum",
+			fieldType: FieldInt,
+			sample:    1,
+			enums:     []string{"1;many"},
+			wantErr:   ErrInvalidEnum,
+		},
+		{
+			name:      "bool enum",
+			fieldType: FieldBool,
+			sample:    true,
+			enums:     []string{"true"},
+			wantErr:   ErrInvalidEnum,
+		},
+		{
+			name:      "empty enum",
+			fieldType: FieldString,
+			sample:    "small",
+			enums:     []string{";"},
+			wantErr:   ErrInvalidEnum,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &APIFields{Name: "Spec", Type: FieldStruct}
+			require.NoError(t, api.AddField("size", tt.fieldType, nil, tt.sample, tt.hasDefault))
+
+			var err error
+
+			for i := range tt.enums {
+				if err = api.setEnum("size", &tt.enums[i]); err != nil {
+					break
+				}
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, api.getChild("Size").Markers)
+		})
+	}
+}
+
+func TestFieldMarker_Inspect_Enum(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webapp
+  labels:
+    size: small  # +operator-builder:field:name=size,type=string,default="small",enum="small;medium;large"
+`)
+
+	_, results, err := inspectMarkersForYAML(content, FieldMarkerType)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	fm, ok := results[0].Object.(FieldMarker)
+	require.True(t, ok)
+
+	require.NotNil(t, fm.Enum)
+	assert.Equal(t, "small;medium;large", *fm.Enum)
+}
@@ -0,0 +1,484 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+)
+
+var (
+	ErrNoManifestsFound = errors.New("no kubernetes manifests found")
+	ErrInvalidGroupBy   = errors.New("invalid manifest grouping")
+)
+
+// Manifest groupings used when proposing the components of a collection.
+const (
+	GroupByDirectory = "directory"
+	GroupByNamespace = "namespace"
+)
+
+const (
+	collectionNameSuffix = "collection"
+	defaultWorkloadName  = "workload"
+)
+
+// ProposeConfigOptions defines the inputs used to propose a workload config from a
+// directory of manifests.
+type ProposeConfigOptions struct {
+	// ManifestsPath is the directory which is scanned for manifests.
+	ManifestsPath string
+
+	// ConfigPath is the path the workload config will be written to.  Resource paths
+	// in the proposal are relative to the directory of this path.
+	ConfigPath string
+
+	// GroupBy determines how manifests are grouped into components.  It is only used
+	// when proposing a collection.
+	GroupBy string
+
+	// Collection requests that manifests be grouped into components of a collection.
+	Collection bool
+}
+
+// ConfigProposal contains the workload config values which have been inferred from a
+// directory of manifests.
+type ConfigProposal struct {
+	Name       string
+	API        WorkloadAPISpec
+	Resources  []string
+	Components []*ComponentProposal
+
+	// ManifestFiles is the number of manifest files which were discovered, including
+	// those which are owned by the components of a collection.
+	ManifestFiles int
+}
+
+// ComponentProposal contains the component config values which have been inferred
+// from a group of manifests.
+type ComponentProposal struct {
+	Name      string
+	API       WorkloadAPISpec
+	Resources []string
+}
+
+// manifestInfo contains the information about a manifest file that is used to
+// propose a workload config.
+type manifestInfo struct {
+	path          string
+	directory     string
+	namespaces    []string
+	clusterScoped bool
+}
+
+// ProposeConfig scans a directory of manifests and proposes the workload config values
+// for either a single workload or a collection of components.
+func ProposeConfig(options *ProposeConfigOptions) (*ConfigProposal, error) {
+	configDir := "."
+	if options.ConfigPath != "-" && options.ConfigPath != "" {
+		configDir = filepath.Dir(options.ConfigPath)
+	}
+
+	manifests, err := scanManifests(options.ManifestsPath, configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestsDir, err := filepath.Abs(options.ManifestsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine absolute file path, %w", err)
+	}
+
+	name := proposeName(filepath.Base(manifestsDir))
+
+	proposal := &ConfigProposal{
+		Name:          name,
+		API:           *NewSampleAPISpec(),
+		ManifestFiles: len(manifests),
+	}
+
+	proposal.API.Kind = utils.ToPascalCase(name)
+
+	if !options.Collection {
+		proposal.Resources = manifestPaths(manifests)
+		proposal.API.ClusterScoped = manifestsClusterScoped(manifests)
+
+		return proposal, nil
+	}
+
+	proposal.Name = fmt.Sprintf("%s-%s", name, collectionNameSuffix)
+	proposal.API.Kind = utils.ToPascalCase(proposal.Name)
+	proposal.API.ClusterScoped = true
+
+	groups, err := groupManifests(manifests, options.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range sortedKeys(groups) {
+		// manifests which do not belong to a group are owned by the collection
+		if key == "" {
+			proposal.Resources = manifestPaths(groups[key])
+
+			continue
+		}
+
+		componentName := proposeName(key)
+
+		component := &ComponentProposal{
+			Name:      fmt.Sprintf("%s-component", componentName),
+			API:       proposal.API,
+			Resources: manifestPaths(groups[key]),
+		}
+
+		component.API.Kind = utils.ToPascalCase(componentName)
+		component.API.ClusterScoped = manifestsClusterScoped(groups[key])
+
+		proposal.Components = append(proposal.Components, component)
+	}
+
+	return proposal, nil
+}
+
+// SetName overrides the proposed name if a non-empty name was requested.
+func (p *ConfigProposal) SetName(name string) {
+	p.Name = valueOrDefault(name, p.Name)
+}
+
+// SetAPI overrides the proposed api values with any non-empty values that were
+// requested.  The domain, group and version are applied to the components of a
+// collection as well.
+func (p *ConfigProposal) SetAPI(domain, group, version, kind string) {
+	for _, api := range p.apiSpecs() {
+		api.Domain = valueOrDefault(domain, api.Domain)
+		api.Group = valueOrDefault(group, api.Group)
+		api.Version = valueOrDefault(version, api.Version)
+	}
+
+	p.API.Kind = valueOrDefault(kind, p.API.Kind)
+}
+
+// ComponentFile returns the file name, relative to the collection config, that a
+// proposed component config is written to.
+func (c *ComponentProposal) ComponentFile() string {
+	return fmt.Sprintf("%s.yaml", c.Name)
+}
+
+// Standalone returns a standalone workload built from the proposal.
+func (p *ConfigProposal) Standalone() *StandaloneWorkload {
+	workload := NewStandaloneWorkload(p.Name, p.API, p.Resources)
+
+	workload.Spec.CompanionCliRootcmd.SetDefaults(workload, false)
+
+	return workload
+}
+
+// Collection returns a workload collection built from the proposal.
+func (p *ConfigProposal) Collection() *WorkloadCollection {
+	componentFiles := make([]string, len(p.Components))
+
+	for i, component := range p.Components {
+		componentFiles[i] = component.ComponentFile()
+	}
+
+	workload := NewWorkloadCollection(p.Name, p.API, componentFiles)
+
+	workload.Spec.CompanionCliRootcmd.SetDefaults(workload, false)
+	workload.Spec.CompanionCliSubcmd.SetDefaults(workload, true)
+	workload.Spec.Resources = getResourcesFromFiles(p.Resources)
+
+	return workload
+}
+
+// Component returns a component workload built from the proposal of a standalone
+// workload.
+func (p *ConfigProposal) Component() *ComponentWorkload {
+	return (&ComponentProposal{Name: p.Name, API: p.API, Resources: p.Resources}).Component()
+}
+
+// Component returns a component workload built from the proposal.
+func (c *ComponentProposal) Component() *ComponentWorkload {
+	workload := NewComponentWorkload(c.Name, c.API, c.Resources, []string{})
+
+	workload.Spec.CompanionCliSubcmd.SetDefaults(workload, true)
+
+	return workload
+}
+
+func (p *ConfigProposal) apiSpecs() []*WorkloadAPISpec {
+	specs := []*WorkloadAPISpec{&p.API}
+
+	for _, component := range p.Components {
+		specs = append(specs, &component.API)
+	}
+
+	return specs
+}
+
+// scanManifests walks a directory and returns information about each file which
+// contains kubernetes manifests.  Paths are returned relative to the config directory.
+func scanManifests(manifestsPath, configDir string) ([]*manifestInfo, error) {
+	var manifests []*manifestInfo
+
+	err := filepath.Walk(manifestsPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		manifest, err := inspectManifestFile(path)
+		if err != nil {
+			return err
+		}
+
+		// skip files which contain no kubernetes objects, such as workload configs
+		if manifest == nil {
+			return nil
+		}
+
+		if manifest.path, err = relativePath(configDir, path); err != nil {
+			return err
+		}
+
+		if manifest.directory, err = relativePath(manifestsPath, filepath.Dir(path)); err != nil {
+			return err
+		}
+
+		manifests = append(manifests, manifest)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan manifests at %s, %w", manifestsPath, err)
+	}
+
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("%w at %s", ErrNoManifestsFound, manifestsPath)
+	}
+
+	return manifests, nil
+}
+
+// inspectManifestFile returns the namespaces and scope of the kubernetes objects in a
+// manifest file.  A nil result is returned if the file contains no kubernetes objects.
+func inspectManifestFile(path string) (*manifestInfo, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest %s, %w", path, err)
+	}
+
+	manifest := &manifestInfo{}
+
+	var found bool
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var object struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+
+		if err := decoder.Decode(&object); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to decode manifest %s, %w", path, err)
+		}
+
+		// anything without an api version and kind is not a kubernetes object, and
+		// workload configs and kustomizations are not managed by a workload
+		if object.APIVersion == "" || object.Kind == "" || isConfigAPIVersion(object.APIVersion) {
+			continue
+		}
+
+		found = true
+
+		if isClusterScopedKind(object.Kind) {
+			manifest.clusterScoped = true
+		} else if object.Metadata.Namespace != "" && !containsString(manifest.namespaces, object.Metadata.Namespace) {
+			manifest.namespaces = append(manifest.namespaces, object.Metadata.Namespace)
+		}
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return manifest, nil
+}
+
+// groupManifests groups manifests into the components of a collection.  Manifests
+// which belong to no group are stored under the empty key.
+func groupManifests(manifests []*manifestInfo, groupBy string) (map[string][]*manifestInfo, error) {
+	groups := map[string][]*manifestInfo{}
+
+	for _, manifest := range manifests {
+		var key string
+
+		switch groupBy {
+		case GroupByDirectory, "":
+			if manifest.directory != "." {
+				key = filepath.ToSlash(manifest.directory)
+			}
+		case GroupByNamespace:
+			if len(manifest.namespaces) > 0 {
+				key = manifest.namespaces[0]
+			}
+		default:
+			return nil, fmt.Errorf("%w %q - valid groupings: %s, %s",
+				ErrInvalidGroupBy, groupBy, GroupByDirectory, GroupByNamespace)
+		}
+
+		groups[key] = append(groups[key], manifest)
+	}
+
+	return groups, nil
+}
+
+// manifestsClusterScoped determines if a workload managing the manifests should be
+// cluster scoped.  This is the case when cluster scoped objects are managed, or when
+// objects are spread across namespaces, as a namespaced parent may not own them.
+func manifestsClusterScoped(manifests []*manifestInfo) bool {
+	var namespaces []string
+
+	for _, manifest := range manifests {
+		if manifest.clusterScoped {
+			return true
+		}
+
+		for _, namespace := range manifest.namespaces {
+			if !containsString(namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+
+	return len(namespaces) > 1
+}
+
+func manifestPaths(manifests []*manifestInfo) []string {
+	paths := make([]string, len(manifests))
+
+	for i, manifest := range manifests {
+		paths[i] = manifest.path
+	}
+
+	return paths
+}
+
+// proposeName converts a directory or namespace name into a kebab-case name.
+func proposeName(value string) string {
+	name := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(value), "-"), "-")
+	if name == "" {
+		return defaultWorkloadName
+	}
+
+	return name
+}
+
+func clusterScopedKinds() []string {
+	return []string{
+		"APIService",
+		"ClusterRole",
+		"ClusterRoleBinding",
+		"CSIDriver",
+		"CustomResourceDefinition",
+		"IngressClass",
+		"MutatingWebhookConfiguration",
+		"Namespace",
+		"Node",
+		"PersistentVolume",
+		"PodSecurityPolicy",
+		"PriorityClass",
+		"RuntimeClass",
+		"StorageClass",
+		"ValidatingWebhookConfiguration",
+	}
+}
+
+func isClusterScopedKind(kind string) bool {
+	return containsString(clusterScopedKinds(), kind)
+}
+
+func isConfigAPIVersion(apiVersion string) bool {
+	for _, group := range []string{WorkloadConfigGroup, "kustomize.config.k8s.io"} {
+		if strings.HasPrefix(apiVersion, group+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func relativePath(base, path string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine absolute file path, %w", err)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine absolute file path, %w", err)
+	}
+
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine relative file path, %w", err)
+	}
+
+	return rel, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func sortedKeys(groups map[string][]*manifestInfo) []string {
+	keys := make([]string, 0, len(groups))
+
+	for key := range groups {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
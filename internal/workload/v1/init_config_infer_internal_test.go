@@ -0,0 +1,146 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_groupManifests(t *testing.T) {
+	t.Parallel()
+
+	root := &manifestInfo{path: "ns.yaml", directory: "."}
+	ingress := &manifestInfo{path: "ingress/deploy.yaml", directory: "ingress", namespaces: []string{"ingress-system"}}
+	tenancy := &manifestInfo{path: "tenancy/deploy.yaml", directory: "tenancy", namespaces: []string{"tenancy-system"}}
+
+	tests := []struct {
+		name     string
+		groupBy  string
+		expected map[string][]*manifestInfo
+		wantErr  bool
+	}{
+		{
+			name:    "group by directory",
+			groupBy: GroupByDirectory,
+			expected: map[string][]*manifestInfo{
+				"":        {root},
+				"ingress": {ingress},
+				"tenancy": {tenancy},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "group by namespace",
+			groupBy: GroupByNamespace,
+			expected: map[string][]*manifestInfo{
+				"":               {root},
+				"ingress-system": {ingress},
+				"tenancy-system": {tenancy},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid grouping",
+			groupBy: "kind",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := groupManifests([]*manifestInfo{root, ingress, tenancy}, tt.groupBy)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func Test_manifestsClusterScoped(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		manifests []*manifestInfo
+		expected  bool
+	}{
+		{
+			name: "single namespace is namespace scoped",
+			manifests: []*manifestInfo{
+				{namespaces: []string{"webstore"}},
+				{namespaces: []string{"webstore"}},
+			},
+			expected: false,
+		},
+		{
+			name: "multiple namespaces are cluster scoped",
+			manifests: []*manifestInfo{
+				{namespaces: []string{"webstore"}},
+				{namespaces: []string{"payments"}},
+			},
+			expected: true,
+		},
+		{
+			name: "cluster scoped objects are cluster scoped",
+			manifests: []*manifestInfo{
+				{clusterScoped: true},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, manifestsClusterScoped(tt.manifests))
+		})
+	}
+}
+
+func Test_proposeName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "mixed case with separators",
+			value:    "My_Web.Store",
+			expected: "my-web-store",
+		},
+		{
+			name:     "nested directory",
+			value:    "apps/ingress",
+			expected: "apps-ingress",
+		},
+		{
+			name:     "no usable characters",
+			value:    "..",
+			expected: defaultWorkloadName,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, proposeName(tt.value))
+		})
+	}
+}
@@ -39,6 +39,8 @@ type WorkloadAPIBuilder interface {
 
 	HasSubCmdName() bool
 	HasChildResources() bool
+	HasWebhooks() bool
+	HasPausedField() bool
 
 	GetName() string
 	GetPackageName() string
@@ -46,11 +48,20 @@ type WorkloadAPIBuilder interface {
 	GetAPIGroup() string
 	GetAPIVersion() string
 	GetAPIKind() string
+	GetAPIShortNames() []string
+	GetAPICategories() []string
 	GetDependencies() []*ComponentWorkload
 	GetCollection() *WorkloadCollection
 	GetComponents() []*ComponentWorkload
 	GetSourceFiles() *[]SourceFile
 	GetAPISpecFields() *APIFields
+	GetStatusFields() []*StatusField
+	GetReadyKinds() []string
+	GetReadyConditions() []*ReadyCondition
+	GetDeleteWaves() []*DeleteWave
+	GetDriftRules() []*DriftRule
+	GetRequeueRules() []*RequeueRule
+	GetControllerOptions() *ControllerOptions
 	GetRBACRules() *[]RBACRule
 	GetOwnershipRules() *[]OwnershipRule
 	GetComponentResource(domain, repo string, clusterScoped bool) *resource.Resource
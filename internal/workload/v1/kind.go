@@ -5,7 +5,6 @@ package v1
 
 import (
 	"errors"
-	"fmt"
 
 	"gopkg.in/yaml.v3"
 )
@@ -62,30 +61,3 @@ func workloadKindsMap() map[string]WorkloadKind {
 		"ComponentWorkload":  WorkloadKindComponent,
 	}
 }
-
-func decodeStandalone(dc *yaml.Decoder) (*StandaloneWorkload, error) {
-	v := &StandaloneWorkload{}
-	if err := dc.Decode(v); err != nil {
-		return nil, fmt.Errorf("%w", err)
-	}
-
-	return v, nil
-}
-
-func decodeCollection(dc *yaml.Decoder) (*WorkloadCollection, error) {
-	v := &WorkloadCollection{}
-	if err := dc.Decode(v); err != nil {
-		return nil, fmt.Errorf("%w", err)
-	}
-
-	return v, nil
-}
-
-func decodeComponent(dc *yaml.Decoder) (*ComponentWorkload, error) {
-	v := &ComponentWorkload{}
-	if err := dc.Decode(v); err != nil {
-		return nil, fmt.Errorf("%w", err)
-	}
-
-	return v, nil
-}
@@ -23,12 +23,22 @@ const (
 	FieldMarkerType MarkerType = iota
 	CollectionMarkerType
 	ResourceMarkerType
+	ValidationMarkerType
+	StatusMarkerType
+	ReadyMarkerType
+	DeleteMarkerType
+	DriftMarkerType
 )
 
 const (
 	collectionFieldMarker = "+operator-builder:collection:field"
 	fieldMarker           = "+operator-builder:field"
 	resourceMarker        = "+operator-builder:resource"
+	validationMarker      = "+operator-builder:validation"
+	statusMarker          = "+operator-builder:status"
+	readyMarker           = "+operator-builder:ready"
+	deleteMarker          = "+operator-builder:delete"
+	driftMarker           = "+operator-builder:drift"
 
 	collectionFieldSpecPrefix = "collection.Spec"
 	fieldSpecPrefix           = "parent.Spec"
@@ -46,6 +56,66 @@ type FieldMarker struct {
 	Default       interface{} `marker:",optional"`
 	Replace       *string
 	originalValue interface{}
+
+	// RequiredIf and MutuallyExclusive are cross-field validation rules which are enforced
+	// by the validating webhook of the workload.  RequiredIf is the name of another field,
+	// optionally followed by =value, which requires this field when it is set.
+	// MutuallyExclusive is the name of another field which may not be set with this one.
+	RequiredIf        *string
+	MutuallyExclusive *string
+
+	// PrinterColumn displays the field as a column of kubectl get for the workload, under
+	// PrinterColumnName when set.  A column with a PrinterColumnPriority greater than zero
+	// is only displayed in wide output.
+	PrinterColumn         *bool
+	PrinterColumnName     *string
+	PrinterColumnPriority *int
+
+	// Enum restricts the field to a list of values separated by semicolons, which is
+	// validated by the API server.
+	Enum *string
+}
+
+// ValidationMarker is a CEL rule which is validated by the API server.  The rule applies
+// to the field named by Field, or to the spec itself when no field is given.
+type ValidationMarker struct {
+	Rule    string
+	Message *string
+	Field   *string
+}
+
+// StatusMarker adds a field named Field to the status of the workload, which is copied
+// from the value at Path of the child resource on which the marker is placed.  The field
+// is a string unless a Type is given.
+type StatusMarker struct {
+	Field       string
+	Path        string
+	Type        FieldType `marker:",optional"`
+	Description *string
+}
+
+// ReadyMarker is a readiness condition of the child resource on which the marker is placed.
+// The child resource is ready when the value at the JSONPath Path is Value, or is set when
+// no Value is given.
+type ReadyMarker struct {
+	Path  string
+	Value *string
+}
+
+// DeleteMarker sets how the child resource on which the marker is placed is torn down when
+// the workload is deleted.  The Policy is one of delete, orphan or retain, and child resources
+// are deleted in reverse Wave order.  Both default by the kind of the child resource.
+type DeleteMarker struct {
+	Policy *string
+	Wave   *int
+}
+
+// DriftMarker sets how the fields of the child resource on which the marker is placed are
+// handled when they are changed by another field manager.  The changed fields are reverted
+// unless Revert is false, and the field at the path Ignore is never reverted or reported.
+type DriftMarker struct {
+	Revert *bool
+	Ignore *string
 }
 
 type ResourceMarker struct {
@@ -110,6 +180,132 @@ func defineCollectionFieldMarker(registry *marker.Registry) error {
 	return nil
 }
 
+func (vm ValidationMarker) String() string {
+	var field, message string
+
+	if vm.Field != nil {
+		field = *vm.Field
+	}
+
+	if vm.Message != nil {
+		message = *vm.Message
+	}
+
+	return fmt.Sprintf("ValidationMarker{Rule: %q Message: %q Field: %s}",
+		vm.Rule,
+		message,
+		field,
+	)
+}
+
+func defineValidationMarker(registry *marker.Registry) error {
+	validationMarker, err := marker.Define(validationMarker, ValidationMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(validationMarker)
+
+	return nil
+}
+
+func (sm StatusMarker) String() string {
+	return fmt.Sprintf("StatusMarker{Field: %s Path: %s}",
+		sm.Field,
+		sm.Path,
+	)
+}
+
+func defineStatusMarker(registry *marker.Registry) error {
+	statusMarker, err := marker.Define(statusMarker, StatusMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(statusMarker)
+
+	return nil
+}
+
+func (rm ReadyMarker) String() string {
+	var value string
+	if rm.Value != nil {
+		value = *rm.Value
+	}
+
+	return fmt.Sprintf("ReadyMarker{Path: %s Value: %s}",
+		rm.Path,
+		value,
+	)
+}
+
+func defineReadyMarker(registry *marker.Registry) error {
+	readyMarker, err := marker.Define(readyMarker, ReadyMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(readyMarker)
+
+	return nil
+}
+
+func (dm DeleteMarker) String() string {
+	var policy string
+	if dm.Policy != nil {
+		policy = *dm.Policy
+	}
+
+	var wave string
+	if dm.Wave != nil {
+		wave = fmt.Sprint(*dm.Wave)
+	}
+
+	return fmt.Sprintf("DeleteMarker{Policy: %s Wave: %s}",
+		policy,
+		wave,
+	)
+}
+
+func defineDeleteMarker(registry *marker.Registry) error {
+	deleteMarker, err := marker.Define(deleteMarker, DeleteMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(deleteMarker)
+
+	return nil
+}
+
+func (dm DriftMarker) String() string {
+	var revert string
+	if dm.Revert != nil {
+		revert = fmt.Sprint(*dm.Revert)
+	}
+
+	var ignore string
+	if dm.Ignore != nil {
+		ignore = *dm.Ignore
+	}
+
+	return fmt.Sprintf("DriftMarker{Revert: %s Ignore: %s}",
+		revert,
+		ignore,
+	)
+}
+
+func defineDriftMarker(registry *marker.Registry) error {
+	driftMarker, err := marker.Define(driftMarker, DriftMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(driftMarker)
+
+	return nil
+}
+
 //nolint:gocritic //needed to implement string interface
 func (rm ResourceMarker) String() string {
 	return fmt.Sprintf("ResourceMarker{Field: %s CollectionField: %s Value: %v Include: %v}",
@@ -162,6 +358,16 @@ func InitializeMarkerInspector(markerTypes ...MarkerType) (*inspect.Inspector, e
 			err = defineCollectionFieldMarker(registry)
 		case ResourceMarkerType:
 			err = defineResourceMarker(registry)
+		case ValidationMarkerType:
+			err = defineValidationMarker(registry)
+		case StatusMarkerType:
+			err = defineStatusMarker(registry)
+		case ReadyMarkerType:
+			err = defineReadyMarker(registry)
+		case DeleteMarkerType:
+			err = defineDeleteMarker(registry)
+		case DriftMarkerType:
+			err = defineDriftMarker(registry)
 		}
 	}
 
@@ -258,6 +464,40 @@ func TransformYAML(results ...*inspect.YAMLResult) error {
 			}
 
 			r.Object = t
+
+		case ValidationMarker:
+			key.HeadComment = strings.ReplaceAll(key.HeadComment, replaceText, "validated by rule: "+t.Rule)
+			value.LineComment = strings.ReplaceAll(value.LineComment, replaceText, "validated by rule: "+t.Rule)
+
+		case StatusMarker:
+			key.HeadComment = strings.ReplaceAll(key.HeadComment, replaceText, "copied to status field: "+t.Field)
+			value.LineComment = strings.ReplaceAll(value.LineComment, replaceText, "copied to status field: "+t.Field)
+
+		case ReadyMarker:
+			key.HeadComment = strings.ReplaceAll(key.HeadComment, replaceText, "readiness checked at: "+t.Path)
+			value.LineComment = strings.ReplaceAll(value.LineComment, replaceText, "readiness checked at: "+t.Path)
+
+		case DeleteMarker:
+			policy := DeletePolicyDelete
+			if t.Policy != nil {
+				policy = *t.Policy
+			}
+
+			key.HeadComment = strings.ReplaceAll(key.HeadComment, replaceText, "deleted with policy: "+policy)
+			value.LineComment = strings.ReplaceAll(value.LineComment, replaceText, "deleted with policy: "+policy)
+
+		case DriftMarker:
+			text := "drift reverted"
+			if t.Revert != nil && !*t.Revert {
+				text = "drift reported"
+			}
+
+			if t.Ignore != nil {
+				text = "drift ignored at: " + *t.Ignore
+			}
+
+			key.HeadComment = strings.ReplaceAll(key.HeadComment, replaceText, text)
+			value.LineComment = strings.ReplaceAll(value.LineComment, replaceText, text)
 		}
 	}
 
@@ -0,0 +1,315 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidConfigDocument = errors.New("workload config document must be a yaml mapping")
+
+const (
+	apiVersionKey     = "apiVersion"
+	kindKey           = "kind"
+	specKey           = "spec"
+	componentFilesKey = "componentFiles"
+)
+
+// configMigration upgrades a single workload config document from one config
+// version to the next.  The migration updates the document and returns the edits
+// which make the same change to the text of the config.
+type configMigration struct {
+	from    string
+	to      string
+	migrate func(document *yaml.Node) ([]configEdit, error)
+}
+
+// configEdit is a line which is inserted into the text of a workload config before
+// the line with the provided number, at the provided column, so that the rest of the
+// config keeps its formatting.
+type configEdit struct {
+	line    int
+	column  int
+	content string
+}
+
+// configMigrations returns the ordered chain of migrations used to move a workload
+// config from any supported version to the latest version.
+func configMigrations() []configMigration {
+	return []configMigration{
+		{
+			from:    WorkloadConfigVersionLegacy,
+			to:      WorkloadConfigVersionV1,
+			migrate: migrateLegacyToV1,
+		},
+	}
+}
+
+// MigrateConfig rewrites the workload config at the provided path, and all of the
+// component configs that it references, to the latest workload config version.
+// Only the lines which are changed by a migration are rewritten, so that comments,
+// key ordering and formatting of the original configs are preserved.  The paths of
+// all files which were rewritten are returned.
+func MigrateConfig(workloadConfig string) ([]string, error) {
+	if workloadConfig == "" {
+		return nil, ErrConfigMustExist
+	}
+
+	var migrated []string
+
+	if err := migrateConfigFile(workloadConfig, &migrated); err != nil {
+		return migrated, err
+	}
+
+	return migrated, nil
+}
+
+func migrateConfigFile(configPath string, migrated *[]string) error {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("unable to read workload config %s, %w", configPath, err)
+	}
+
+	documents, err := decodeConfigNodes(content)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", configPath, err)
+	}
+
+	var edits []configEdit
+
+	for _, document := range documents {
+		documentEdits, err := migrateConfigNode(document)
+		if err != nil {
+			return fmt.Errorf("unable to migrate workload config %s, %w", configPath, err)
+		}
+
+		edits = append(edits, documentEdits...)
+
+		// migrate the component configs referenced by a collection
+		for _, componentFile := range configComponentFiles(document) {
+			componentPath := filepath.Join(filepath.Dir(configPath), componentFile)
+
+			if err := migrateConfigFile(componentPath, migrated); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(edits) == 0 {
+		return nil
+	}
+
+	data := applyConfigEdits(content, edits)
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return fmt.Errorf("unable to stat workload config %s, %w", configPath, err)
+	}
+
+	if err := os.WriteFile(configPath, data, info.Mode()); err != nil {
+		return fmt.Errorf("%w; %s at location %s", err, ErrWriteFile, configPath)
+	}
+
+	*migrated = append(*migrated, configPath)
+
+	return nil
+}
+
+// migrateConfigNode runs the migrations needed to bring a single workload config
+// document to the latest version.  It returns the edits to the text of the document,
+// which are empty when the document is already at the latest version.
+func migrateConfigNode(document *yaml.Node) ([]configEdit, error) {
+	mapping := documentMapping(document)
+	if mapping == nil {
+		return nil, ErrInvalidConfigDocument
+	}
+
+	version := WorkloadConfigVersionLegacy
+	if value := mappingValue(mapping, apiVersionKey); value != nil {
+		version = value.Value
+	}
+
+	if _, ok := configVersionsMap()[version]; !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedConfigVersion, version)
+	}
+
+	var edits []configEdit
+
+	for _, migration := range configMigrations() {
+		if migration.from != version {
+			continue
+		}
+
+		migrationEdits, err := migration.migrate(mapping)
+		if err != nil {
+			return edits, fmt.Errorf("unable to migrate from version %q to %q, %w", migration.from, migration.to, err)
+		}
+
+		edits = append(edits, migrationEdits...)
+		version = migration.to
+	}
+
+	return edits, nil
+}
+
+// migrateLegacyToV1 adds the apiVersion field to a config which was written prior
+// to config versioning.  The field is added as the first key of the document, below
+// any head comment of the previous first key, so that comments at the top of a file
+// remain at the top of the file.
+func migrateLegacyToV1(mapping *yaml.Node) ([]configEdit, error) {
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: apiVersionKey}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: WorkloadConfigVersionV1}
+
+	edit := configEdit{
+		line:    mapping.Line,
+		column:  mapping.Column,
+		content: fmt.Sprintf("%s: %s", apiVersionKey, WorkloadConfigVersionV1),
+	}
+
+	if len(mapping.Content) > 0 {
+		edit.line, edit.column = mapping.Content[0].Line, mapping.Content[0].Column
+	}
+
+	mapping.Content = append([]*yaml.Node{key, value}, mapping.Content...)
+
+	return []configEdit{edit}, nil
+}
+
+// applyConfigEdits inserts the lines of the edits into the text of a workload config,
+// leaving the other lines of the config as they are.
+func applyConfigEdits(content []byte, edits []configEdit) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+
+	// insert from the last line so that the line numbers of the other edits still apply
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].line > edits[j].line
+	})
+
+	for _, edit := range edits {
+		index := edit.line - 1
+		if index < 0 || index > len(lines) {
+			index = 0
+		}
+
+		indent := ""
+		if edit.column > 1 {
+			indent = strings.Repeat(" ", edit.column-1)
+		}
+
+		line := indent + edit.content + "\n"
+
+		lines = append(lines[:index], append([]string{line}, lines[index:]...)...)
+	}
+
+	return []byte(strings.Join(lines, ""))
+}
+
+// configComponentFiles returns the component files referenced by a collection
+// config document.
+func configComponentFiles(document *yaml.Node) []string {
+	mapping := documentMapping(document)
+	if mapping == nil {
+		return nil
+	}
+
+	if kind := mappingValue(mapping, kindKey); kind == nil || kind.Value != WorkloadKindCollection.String() {
+		return nil
+	}
+
+	spec := mappingValue(mapping, specKey)
+	if spec == nil {
+		return nil
+	}
+
+	componentFiles := mappingValue(spec, componentFilesKey)
+	if componentFiles == nil || componentFiles.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	files := make([]string, 0, len(componentFiles.Content))
+
+	for _, file := range componentFiles.Content {
+		files = append(files, file.Value)
+	}
+
+	return files
+}
+
+func decodeConfigNodes(content []byte) ([]*yaml.Node, error) {
+	var documents []*yaml.Node
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var document yaml.Node
+
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		documents = append(documents, &document)
+	}
+
+	return documents, nil
+}
+
+func encodeConfigNodes(documents []*yaml.Node) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(indentLevel)
+
+	for _, document := range documents {
+		if err := encoder.Encode(document); err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// documentMapping returns the top level mapping of a yaml document.
+func documentMapping(document *yaml.Node) *yaml.Node {
+	node := document
+
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	return node
+}
+
+// mappingValue returns the value node for a key within a yaml mapping.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
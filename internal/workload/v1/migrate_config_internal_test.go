@@ -0,0 +1,209 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_migrateConfigNode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name: "legacy config is migrated to the latest version",
+			input: `# head comment
+name: webstore
+kind: StandaloneWorkload
+spec:
+  api:
+    domain: acme.com # line comment
+`,
+			expected: `# head comment
+apiVersion: workloads.operatorbuilder.io/v1
+name: webstore
+kind: StandaloneWorkload
+spec:
+  api:
+    domain: acme.com # line comment
+`,
+			wantChanged: true,
+			wantErr:     false,
+		},
+		{
+			name: "latest config is left unchanged",
+			input: `apiVersion: workloads.operatorbuilder.io/v1
+name: webstore
+kind: StandaloneWorkload
+`,
+			expected: `apiVersion: workloads.operatorbuilder.io/v1
+name: webstore
+kind: StandaloneWorkload
+`,
+			wantChanged: false,
+			wantErr:     false,
+		},
+		{
+			name: "unsupported config version results in an error",
+			input: `apiVersion: workloads.operatorbuilder.io/v99
+name: webstore
+kind: StandaloneWorkload
+`,
+			wantChanged: false,
+			wantErr:     true,
+		},
+		{
+			name:        "non-mapping document results in an error",
+			input:       "- webstore\n",
+			wantChanged: false,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			documents, err := decodeConfigNodes([]byte(tt.input))
+			require.NoError(t, err)
+			require.Len(t, documents, 1)
+
+			edits, err := migrateConfigNode(documents[0])
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, len(edits) > 0)
+			assert.Equal(t, tt.expected, string(applyConfigEdits([]byte(tt.input), edits)))
+		})
+	}
+}
+
+func TestMigrateConfig_keepsFormatting(t *testing.T) {
+	t.Parallel()
+
+	input := `# the collection of the platform
+name: platform
+kind: WorkloadCollection
+spec:
+  api:
+    domain: acme.com
+  componentFiles:
+  - component.yaml
+  resources:
+  - resources.yaml     # aligned comment
+---
+# second document
+name: other
+kind: StandaloneWorkload
+spec:
+  resources: [a.yaml, b.yaml]
+`
+
+	expected := `# the collection of the platform
+apiVersion: workloads.operatorbuilder.io/v1
+name: platform
+kind: WorkloadCollection
+spec:
+  api:
+    domain: acme.com
+  componentFiles:
+  - component.yaml
+  resources:
+  - resources.yaml     # aligned comment
+---
+# second document
+apiVersion: workloads.operatorbuilder.io/v1
+name: other
+kind: StandaloneWorkload
+spec:
+  resources: [a.yaml, b.yaml]
+`
+
+	component := `name: component
+kind: ComponentWorkload
+spec:
+  resources:
+  - component-resources.yaml
+`
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "workload.yaml")
+	componentPath := filepath.Join(dir, "component.yaml")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(input), 0o600))
+	require.NoError(t, os.WriteFile(componentPath, []byte(component), 0o600))
+
+	migrated, err := MigrateConfig(configPath)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{configPath, componentPath}, migrated)
+
+	actual, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+
+	actual, err = os.ReadFile(componentPath)
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: workloads.operatorbuilder.io/v1\n"+component, string(actual))
+}
+
+func Test_configComponentFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name: "collection returns component files",
+			input: `name: platform
+kind: WorkloadCollection
+spec:
+  componentFiles:
+    - tenancy/component.yaml
+    - ingress/component.yaml
+`,
+			expected: []string{"tenancy/component.yaml", "ingress/component.yaml"},
+		},
+		{
+			name: "standalone returns no component files",
+			input: `name: webstore
+kind: StandaloneWorkload
+spec:
+  componentFiles:
+    - tenancy/component.yaml
+`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			documents, err := decodeConfigNodes([]byte(tt.input))
+			require.NoError(t, err)
+			require.Len(t, documents, 1)
+
+			assert.Equal(t, tt.expected, configComponentFiles(documents[0]))
+		})
+	}
+}
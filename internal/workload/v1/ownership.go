@@ -8,10 +8,19 @@ import "strings"
 // OwnershipRule contains the info needed to create the controller ownership
 // functionality when setting up the controller with the manager.  This allows
 // the controller to reconcile the state of a deleted resource that it manages.
+// A child resource of a cluster-scoped kind cannot have an owner reference to a
+// namespaced workload, and is watched by its labels instead.  A child resource of
+// which the namespace is set by a field may be placed in another namespace than
+// the workload, where it cannot have an owner reference either.  The status of a
+// child resource is only watched when the status or readiness of the workload is
+// read from it.
 type OwnershipRule struct {
-	Version string
-	Kind    string
-	CoreAPI bool
+	Version       string
+	Kind          string
+	CoreAPI       bool
+	ClusterScoped   bool
+	OtherNamespaces bool
+	WatchStatus     bool
 }
 
 type OwnershipRules []OwnershipRule
@@ -22,9 +31,10 @@ func (or *OwnershipRules) addOrUpdateOwnership(version, kind, group string) {
 	}
 	// determine group and kind for ownership rule generation
 	newOwnershipRule := OwnershipRule{
-		Version: version,
-		Kind:    kind,
-		CoreAPI: isCoreAPI(group),
+		Version:       version,
+		Kind:          kind,
+		CoreAPI:       isCoreAPI(group),
+		ClusterScoped: isClusterScopedKind(kind),
 	}
 
 	if !or.versionKindRecorded(&newOwnershipRule) {
@@ -32,6 +42,25 @@ func (or *OwnershipRules) addOrUpdateOwnership(version, kind, group string) {
 	}
 }
 
+// watchStatus records that the status of the child resources of a kind is watched.
+func (or *OwnershipRules) watchStatus(version, kind string) {
+	for i := range *or {
+		if (*or)[i].Version == version && (*or)[i].Kind == kind {
+			(*or)[i].WatchStatus = true
+		}
+	}
+}
+
+// watchOtherNamespaces records that the child resources of a kind may be placed in another
+// namespace than the workload.
+func (or *OwnershipRules) watchOtherNamespaces(version, kind string) {
+	for i := range *or {
+		if (*or)[i].Version == version && (*or)[i].Kind == kind {
+			(*or)[i].OtherNamespaces = true
+		}
+	}
+}
+
 func (or *OwnershipRules) versionKindRecorded(newOwnershipRule *OwnershipRule) bool {
 	for _, r := range *or {
 		if r.Version == newOwnershipRule.Version && r.Kind == newOwnershipRule.Kind {
@@ -42,6 +71,13 @@ func (or *OwnershipRules) versionKindRecorded(newOwnershipRule *OwnershipRule) b
 	return false
 }
 
+// isFieldNamespace determines if the namespace of a manifest is set by a field of the workload
+// or collection, in which case the resource may be placed in another namespace than the
+// workload.
+func isFieldNamespace(namespace string) bool {
+	return strings.Contains(namespace, fieldSpecPrefix) || strings.Contains(namespace, collectionFieldSpecPrefix)
+}
+
 func coreAPIs() []string {
 	return []string{
 		"apps", "batch", "autoscaling", "extensions", "policy",
@@ -0,0 +1,70 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isFieldNamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		namespace string
+		expected  bool
+	}{
+		{
+			name:      "namespace set by a field",
+			namespace: "parent.Spec.SharedNamespace",
+			expected:  true,
+		},
+		{
+			name:      "namespace set by a collection field",
+			namespace: "collection.Spec.Namespace",
+			expected:  true,
+		},
+		{
+			name:      "namespace partially replaced by a field",
+			namespace: "team-!!start parent.Spec.Team !!end",
+			expected:  true,
+		},
+		{
+			name:      "static namespace",
+			namespace: "shared",
+			expected:  false,
+		},
+		{
+			name:      "no namespace",
+			namespace: "",
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, isFieldNamespace(tt.namespace))
+		})
+	}
+}
+
+func TestOwnershipRules_watchOtherNamespaces(t *testing.T) {
+	t.Parallel()
+
+	rules := &OwnershipRules{}
+	rules.addOrUpdateOwnership("v1", "ConfigMap", "")
+	rules.addOrUpdateOwnership("v1", "Secret", "")
+
+	rules.watchOtherNamespaces("v1", "ConfigMap")
+
+	assert.Equal(t, &OwnershipRules{
+		{Version: "v1", Kind: "ConfigMap", CoreAPI: true, OtherNamespaces: true},
+		{Version: "v1", Kind: "Secret", CoreAPI: true},
+	}, rules)
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrDuplicatePrinterColumn = errors.New("printer column name is used by more than one field")
+
+// defaultPrinterColumns are the names of the columns which are displayed for every
+// workload, from the status of the workload and its age.
+var defaultPrinterColumns = []string{"Created", "Dependencies", "Age"}
+
+// PrinterColumn is a column which displays the value of an api field in the output of
+// kubectl get.
+type PrinterColumn struct {
+	Name     string
+	Type     string
+	JSONPath string
+	Priority int
+}
+
+// String returns the kubebuilder marker which adds the column to the custom resource
+// definition.
+func (pc *PrinterColumn) String() string {
+	marker := fmt.Sprintf("+kubebuilder:printcolumn:name=%q,type=%s,JSONPath=%q", pc.Name, pc.Type, pc.JSONPath)
+
+	if pc.Priority > 0 {
+		marker = fmt.Sprintf("%s,priority=%d", marker, pc.Priority)
+	}
+
+	return marker
+}
+
+// setPrinterColumn sets the printer column of a field marker on the api field at path,
+// when the field marker enables it.  The column is named after the field unless a name
+// is given.
+func (api *APIFields) setPrinterColumn(path string, enabled *bool, name *string, priority *int) {
+	if enabled == nil || !*enabled {
+		return
+	}
+
+	fields := api.findField(path)
+	if fields == nil {
+		return
+	}
+
+	field := fields[len(fields)-1]
+
+	column := &PrinterColumn{
+		Name:     field.Name,
+		Type:     printerColumnType(field.Type),
+		JSONPath: "." + manifestPath(fields),
+	}
+
+	if name != nil && *name != "" {
+		column.Name = *name
+	}
+
+	if priority != nil {
+		column.Priority = *priority
+	}
+
+	field.PrinterColumn = column
+}
+
+// validatePrinterColumns ensures that the name of each printer column is unique, including
+// the default columns which are displayed for every workload.
+func (api *APIFields) validatePrinterColumns() error {
+	names := map[string]bool{}
+
+	for _, name := range defaultPrinterColumns {
+		names[name] = true
+	}
+
+	for _, column := range api.printerColumns() {
+		if names[column.Name] {
+			return fmt.Errorf("%w; %s for field %s", ErrDuplicatePrinterColumn, column.Name, column.JSONPath)
+		}
+
+		names[column.Name] = true
+	}
+
+	return nil
+}
+
+// GeneratePrinterColumns generates the kubebuilder markers for the printer columns of the
+// api fields, in the order in which the fields are defined.
+func (api *APIFields) GeneratePrinterColumns() []string {
+	columns := api.printerColumns()
+	markers := make([]string, len(columns))
+
+	for i, column := range columns {
+		markers[i] = column.String()
+	}
+
+	return markers
+}
+
+func (api *APIFields) printerColumns() []*PrinterColumn {
+	columns := []*PrinterColumn{}
+
+	if api.PrinterColumn != nil {
+		columns = append(columns, api.PrinterColumn)
+	}
+
+	for _, child := range api.Children {
+		columns = append(columns, child.printerColumns()...)
+	}
+
+	return columns
+}
+
+func printerColumnType(fieldType FieldType) string {
+	switch fieldType {
+	case FieldInt:
+		return "integer"
+	case FieldBool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIFields_GeneratePrinterColumns(t *testing.T) {
+	t.Parallel()
+
+	enabled, disabled := true, false
+	name := "Image"
+	priority := 1
+
+	api := &APIFields{Name: "Spec", Type: FieldStruct}
+	require.NoError(t, api.AddField("replicas", FieldInt, nil, 2, true))
+	require.NoError(t, api.AddField("image.name", FieldString, nil, "nginx", true))
+	require.NoError(t, api.AddField("tls.enabled", FieldBool, nil, true, true))
+	require.NoError(t, api.AddField("mode", FieldString, nil, "web", false))
+
+	api.setPrinterColumn("replicas", &enabled, nil, nil)
+	api.setPrinterColumn("image.name", &enabled, &name, nil)
+	api.setPrinterColumn("tls.enabled", &enabled, nil, &priority)
+	api.setPrinterColumn("mode", &disabled, nil, nil)
+
+	require.NoError(t, api.validatePrinterColumns())
+
+	assert.Equal(t, []string{
+		`+kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=".spec.replicas"`,
+		`+kubebuilder:printcolumn:name="Image",type=string,JSONPath=".spec.image.name"`,
+		`+kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=".spec.tls.enabled",priority=1`,
+	}, api.GeneratePrinterColumns())
+}
+
+func TestAPIFields_validatePrinterColumns(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+
+	tests := []struct {
+		name    string
+		columns []string
+		wantErr error
+	}{
+		{
+			name:    "unique names",
+			columns: []string{"Replicas", "Mode"},
+		},
+		{
+			name:    "duplicate names",
+			columns: []string{"Replicas", "Replicas"},
+			wantErr: ErrDuplicatePrinterColumn,
+		},
+		{
+			name:    "default column name",
+			columns: []string{"Age"},
+			wantErr: ErrDuplicatePrinterColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &APIFields{Name: "Spec", Type: FieldStruct}
+			require.NoError(t, api.AddField("replicas", FieldInt, nil, 2, false))
+			require.NoError(t, api.AddField("mode", FieldString, nil, "web", false))
+
+			for i, path := range []string{"replicas", "mode"}[:len(tt.columns)] {
+				api.setPrinterColumn(path, &enabled, &tt.columns[i], nil)
+			}
+
+			err := api.validatePrinterColumns()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestFieldMarker_Inspect_PrinterColumn(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webapp
+spec:
+  replicas: 1  # +operator-builder:field:name=replicas,type=int,printerColumn=true,printerColumnName="Desired",printerColumnPriority=1
+`)
+
+	_, results, err := inspectMarkersForYAML(content, FieldMarkerType)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	fm, ok := results[0].Object.(FieldMarker)
+	require.True(t, ok)
+
+	require.NotNil(t, fm.PrinterColumn)
+	assert.True(t, *fm.PrinterColumn)
+	require.NotNil(t, fm.PrinterColumnName)
+	assert.Equal(t, "Desired", *fm.PrinterColumnName)
+	require.NotNil(t, fm.PrinterColumnPriority)
+	assert.Equal(t, 1, *fm.PrinterColumnPriority)
+}
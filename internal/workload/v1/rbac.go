@@ -42,6 +42,20 @@ func (r *RBACRule) AddVerb(verb string) {
 	}
 }
 
+// IsClusterScoped determines if the rule is for the resources of a cluster-scoped kind, which
+// a role in a namespace may not grant.
+func (r *RBACRule) IsClusterScoped() bool {
+	resource := strings.Split(r.Resource, "/")[0]
+
+	for _, kind := range clusterScopedKinds() {
+		if getResourceForRBAC(kind) == resource {
+			return true
+		}
+	}
+
+	return false
+}
+
 func rbacGroupFromGroup(group string) string {
 	if group == "" {
 		return coreRBACGroup
@@ -50,3 +50,45 @@ func TestRBACRule_AddVerb(t *testing.T) {
 		})
 	}
 }
+
+func TestRBACRule_IsClusterScoped(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resource string
+		want     bool
+	}{
+		{
+			name:     "namespaces are cluster-scoped",
+			resource: "namespaces",
+			want:     true,
+		},
+		{
+			name:     "cluster roles are cluster-scoped",
+			resource: "clusterroles",
+			want:     true,
+		},
+		{
+			name:     "subresources of nodes are cluster-scoped",
+			resource: "nodes/status",
+			want:     true,
+		},
+		{
+			name:     "deployments are namespace-scoped",
+			resource: "deployments",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := &RBACRule{Group: "core", Resource: tt.resource}
+
+			assert.Equal(t, tt.want, rule.IsClusterScoped())
+		})
+	}
+}
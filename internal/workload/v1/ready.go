@@ -0,0 +1,136 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var ErrInvalidReadyPath = errors.New("ready marker path must be a valid JSONPath expression")
+
+// ReadyCondition is a readiness condition of a child resource which is declared by a ready
+// marker.  The child resource is ready when the value at Path is Value, or is set when
+// Value is empty.
+type ReadyCondition struct {
+	Path       string
+	Value      string
+	Comment    string
+	CreateFunc string
+}
+
+// readyKinds returns the kinds of child resources, keyed by their group, for which a default
+// readiness check is generated.
+func readyKinds() map[string][]string {
+	return map[string][]string{
+		coreRBACGroup:          {"Service", "PersistentVolumeClaim"},
+		"apps":                 {"Deployment", "StatefulSet", "DaemonSet"},
+		"batch":                {"Job"},
+		"apiextensions.k8s.io": {"CustomResourceDefinition"},
+	}
+}
+
+// processReadyMarkers adds a readiness condition for each ready marker of the manifest of a
+// child resource and returns the manifest with the markers transformed.  It also records
+// the kind of the child resource when a default readiness check exists for it.
+func (ws *WorkloadSpec) processReadyMarkers(manifest string, child *ChildResource) (string, error) {
+	ws.addReadyKind(child)
+
+	nodes, markerResults, err := inspectMarkersForYAML([]byte(manifest), ReadyMarkerType)
+	if err != nil {
+		return "", err
+	}
+
+	if len(markerResults) == 0 {
+		return manifest, nil
+	}
+
+	for _, markerResult := range markerResults {
+		rm, ok := markerResult.Object.(ReadyMarker)
+		if !ok {
+			continue
+		}
+
+		if err := ws.addReadyCondition(&rm, child); err != nil {
+			return "", err
+		}
+	}
+
+	return marshalManifest(nodes, child)
+}
+
+// addReadyKind records the kind of a child resource when a default readiness check exists
+// for it.  The services of a workload are checked for endpoints, which requires the
+// controller to read them.
+func (ws *WorkloadSpec) addReadyKind(child *ChildResource) {
+	if !isReadyKind(child) {
+		return
+	}
+
+	for _, existing := range ws.ReadyKinds {
+		if existing == child.Kind {
+			return
+		}
+	}
+
+	ws.ReadyKinds = append(ws.ReadyKinds, child.Kind)
+
+	if child.Kind == "Service" {
+		ws.RBACRules.AddOrUpdateRules(
+			&RBACRule{
+				Group:    coreRBACGroup,
+				Resource: "endpoints",
+				Verbs:    []string{"get", "list", "watch"},
+			},
+		)
+	}
+}
+
+// isReadyKind determines if a default readiness check exists for the kind of a child resource.
+func isReadyKind(child *ChildResource) bool {
+	for _, kind := range readyKinds()[child.Group] {
+		if child.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addReadyCondition adds the readiness condition of a ready marker to the child resource
+// after checking that the path is a valid JSONPath expression.
+func (ws *WorkloadSpec) addReadyCondition(rm *ReadyMarker, child *ChildResource) error {
+	path := strings.TrimSuffix(strings.TrimPrefix(rm.Path, "{"), "}")
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+
+	if err := jsonpath.New(child.Name).Parse("{" + path + "}"); err != nil {
+		return fmt.Errorf("%w; %s for marker %s", ErrInvalidReadyPath, rm.Path, rm)
+	}
+
+	// the name of the child resource is omitted when it is controlled by a field
+	resource := child.Kind
+	if !strings.Contains(child.Name, "!!") {
+		resource = fmt.Sprintf("%s %s", child.Name, child.Kind)
+	}
+
+	condition := &ReadyCondition{
+		Path:       path,
+		Comment:    fmt.Sprintf("check that %s of the %s is set", path, resource),
+		CreateFunc: "Create" + child.UniqueName,
+	}
+
+	if rm.Value != nil {
+		condition.Value = *rm.Value
+		condition.Comment = fmt.Sprintf("check that %s of the %s is %s", path, resource, *rm.Value)
+	}
+
+	ws.ReadyConditions = append(ws.ReadyConditions, condition)
+
+	return nil
+}
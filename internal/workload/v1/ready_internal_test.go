@@ -0,0 +1,124 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadSpec_addReadyCondition(t *testing.T) {
+	t.Parallel()
+
+	child := &ChildResource{Name: "webstore-db", Kind: "Cluster", UniqueName: "ClusterWebstoreDb"}
+	ready := "Ready"
+
+	tests := []struct {
+		name    string
+		marker  *ReadyMarker
+		want    *ReadyCondition
+		wantErr error
+	}{
+		{
+			name:   "path with value",
+			marker: &ReadyMarker{Path: ".status.phase", Value: &ready},
+			want: &ReadyCondition{
+				Path:       ".status.phase",
+				Value:      "Ready",
+				Comment:    "check that .status.phase of the webstore-db Cluster is Ready",
+				CreateFunc: "CreateClusterWebstoreDb",
+			},
+		},
+		{
+			name:   "path without value",
+			marker: &ReadyMarker{Path: "status.endpoint"},
+			want: &ReadyCondition{
+				Path:       ".status.endpoint",
+				Comment:    "check that .status.endpoint of the webstore-db Cluster is set",
+				CreateFunc: "CreateClusterWebstoreDb",
+			},
+		},
+		{
+			name:   "filter path in braces",
+			marker: &ReadyMarker{Path: `{.status.conditions[?(@.type=="Ready")].status}`, Value: &ready},
+			want: &ReadyCondition{
+				Path:       `.status.conditions[?(@.type=="Ready")].status`,
+				Value:      "Ready",
+				Comment:    `check that .status.conditions[?(@.type=="Ready")].status of the webstore-db Cluster is Ready`,
+				CreateFunc: "CreateClusterWebstoreDb",
+			},
+		},
+		{
+			name:    "invalid path",
+			marker:  &ReadyMarker{Path: ".status.conditions[?(@.type=="},
+			wantErr: ErrInvalidReadyPath,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{}
+
+			err := ws.addReadyCondition(tt.marker, child)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, ws.ReadyConditions, 1)
+			assert.Equal(t, tt.want, ws.ReadyConditions[0])
+		})
+	}
+}
+
+func TestWorkloadSpec_addReadyKind(t *testing.T) {
+	t.Parallel()
+
+	ws := &WorkloadSpec{RBACRules: &RBACRules{}}
+
+	for _, child := range []*ChildResource{
+		{Name: "webstore-deploy", Group: "apps", Kind: "Deployment"},
+		{Name: "webstore-svc", Group: coreRBACGroup, Kind: "Service"},
+		{Name: "webstore-cm", Group: coreRBACGroup, Kind: "ConfigMap"},
+		{Name: "webstore-api", Group: "apps", Kind: "Deployment"},
+		{Name: "webstore-db", Group: "acme.com", Kind: "Service"},
+	} {
+		ws.addReadyKind(child)
+	}
+
+	assert.Equal(t, []string{"Deployment", "Service"}, ws.ReadyKinds)
+	assert.Equal(t, RBACRules{
+		{Group: coreRBACGroup, Resource: "endpoints", Verbs: []string{"get", "list", "watch"}, VerbString: "get;list;watch"},
+	}, *ws.RBACRules)
+}
+
+func TestWorkloadSpec_processReadyMarkers(t *testing.T) {
+	t.Parallel()
+
+	manifest := `
+# +operator-builder:ready:path=".status.phase",value="Ready"
+apiVersion: acme.com/v1
+kind: Cluster
+metadata:
+  name: webstore-db
+`
+
+	ws := &WorkloadSpec{RBACRules: &RBACRules{}}
+
+	got, err := ws.processReadyMarkers(manifest, &ChildResource{Name: "webstore-db", Group: "acme.com", Kind: "Cluster"})
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "# readiness checked at: .status.phase")
+	assert.NotContains(t, got, "+operator-builder:ready")
+	assert.Empty(t, ws.ReadyKinds)
+	require.Len(t, ws.ReadyConditions, 1)
+	assert.Equal(t, "Ready", ws.ReadyConditions[0].Value)
+}
@@ -0,0 +1,171 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidRequeuePolicy = errors.New("invalid requeue policy")
+
+// defaultRequeueInterval is the interval after which a workload is requeued by a phase which
+// is not ready to proceed, unless the requeue policy of the phase sets another interval.
+const defaultRequeueInterval = 5 * time.Second
+
+// defaultRequeuePhases are the generated phases which wait on other resources and therefore
+// requeue the workload after the default interval when the workload config sets no policy.
+func defaultRequeuePhases() []string {
+	return []string{"Dependency", "Check-Ready", "Cleanup", "Teardown"}
+}
+
+// ControllerSpec defines the attributes of the controller of a workload.
+type ControllerSpec struct {
+	MaxConcurrentReconciles int              `json:"maxConcurrentReconciles,omitempty" yaml:"maxConcurrentReconciles,omitempty"`
+	RateLimiter             *RateLimiterSpec `json:"rateLimiter,omitempty" yaml:"rateLimiter,omitempty" validate:"omitempty"`
+	Requeue                 []*RequeuePolicy `json:"requeue,omitempty" yaml:"requeue,omitempty" validate:"omitempty"`
+}
+
+// RequeuePolicy sets how a workload is requeued by a phase which is not ready to proceed.  The
+// interval is doubled on each consecutive requeue up to the max interval, when one is set, and
+// the workload is no longer requeued once the max retries, when they are set, are reached.
+type RequeuePolicy struct {
+	Phase       string `json:"phase" yaml:"phase"`
+	Interval    string `json:"interval,omitempty" yaml:"interval,omitempty"`
+	MaxInterval string `json:"maxInterval,omitempty" yaml:"maxInterval,omitempty"`
+	MaxRetries  int    `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty"`
+}
+
+// RequeueRule is the requeue policy of a phase with its durations parsed.
+type RequeueRule struct {
+	Phase       string
+	Interval    time.Duration
+	MaxInterval time.Duration
+	MaxRetries  int
+}
+
+// IntervalCode returns the source code of the interval of the rule.
+func (rule *RequeueRule) IntervalCode() string {
+	return durationCode(rule.Interval)
+}
+
+// MaxIntervalCode returns the source code of the max interval of the rule.
+func (rule *RequeueRule) MaxIntervalCode() string {
+	return durationCode(rule.MaxInterval)
+}
+
+// processRequeuePolicies sets the requeue rules of the workload from the requeue policies of
+// the workload config.  The phases which wait on other resources are requeued after the default
+// interval unless the workload config sets a policy for them.
+func (ws *WorkloadSpec) processRequeuePolicies() error {
+	rules := []*RequeueRule{}
+
+	for _, phase := range defaultRequeuePhases() {
+		rules = append(rules, &RequeueRule{Phase: phase, Interval: defaultRequeueInterval})
+	}
+
+	if ws.Controller == nil {
+		ws.RequeueRules = rules
+
+		return nil
+	}
+
+	seen := map[string]bool{}
+
+	for _, policy := range ws.Controller.Requeue {
+		if seen[policy.Phase] {
+			return fmt.Errorf("%w; multiple policies for phase %s", ErrInvalidRequeuePolicy, policy.Phase)
+		}
+
+		seen[policy.Phase] = true
+
+		rule, err := policy.toRule()
+		if err != nil {
+			return err
+		}
+
+		rules = setRequeueRule(rules, rule)
+	}
+
+	ws.RequeueRules = rules
+
+	return nil
+}
+
+// toRule returns the requeue rule of a policy after checking that its values are valid.
+func (policy *RequeuePolicy) toRule() (*RequeueRule, error) {
+	if policy.Phase == "" {
+		return nil, fmt.Errorf("%w; missing phase", ErrInvalidRequeuePolicy)
+	}
+
+	rule := &RequeueRule{
+		Phase:      policy.Phase,
+		Interval:   defaultRequeueInterval,
+		MaxRetries: policy.MaxRetries,
+	}
+
+	var err error
+
+	if policy.Interval != "" {
+		if rule.Interval, err = time.ParseDuration(policy.Interval); err != nil || rule.Interval <= 0 {
+			return nil, fmt.Errorf("%w; interval %q of phase %s must be a positive duration",
+				ErrInvalidRequeuePolicy, policy.Interval, policy.Phase)
+		}
+	}
+
+	if policy.MaxInterval != "" {
+		if rule.MaxInterval, err = time.ParseDuration(policy.MaxInterval); err != nil || rule.MaxInterval < rule.Interval {
+			return nil, fmt.Errorf("%w; maxInterval %q of phase %s must be a duration of at least the interval",
+				ErrInvalidRequeuePolicy, policy.MaxInterval, policy.Phase)
+		}
+	}
+
+	if policy.MaxRetries < 0 {
+		return nil, fmt.Errorf("%w; maxRetries of phase %s must not be negative", ErrInvalidRequeuePolicy, policy.Phase)
+	}
+
+	return rule, nil
+}
+
+// setRequeueRule replaces the rule of the same phase with a rule, or appends the rule when no
+// other rule has its phase.
+func setRequeueRule(rules []*RequeueRule, rule *RequeueRule) []*RequeueRule {
+	for i := range rules {
+		if rules[i].Phase == rule.Phase {
+			rules[i] = rule
+
+			return rules
+		}
+	}
+
+	return append(rules, rule)
+}
+
+// durationCode returns the source code of a duration in the largest unit which it is a whole
+// number of, e.g. 90 * time.Second.
+func durationCode(duration time.Duration) string {
+	units := []struct {
+		duration time.Duration
+		name     string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+
+	if duration == 0 {
+		return "0"
+	}
+
+	for _, unit := range units {
+		if duration%unit.duration == 0 {
+			return fmt.Sprintf("%d * %s", duration/unit.duration, unit.name)
+		}
+	}
+
+	return fmt.Sprintf("%d * time.Nanosecond", duration)
+}
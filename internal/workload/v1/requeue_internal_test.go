@@ -0,0 +1,123 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadSpec_processRequeuePolicies(t *testing.T) {
+	t.Parallel()
+
+	defaults := []*RequeueRule{
+		{Phase: "Dependency", Interval: defaultRequeueInterval},
+		{Phase: "Check-Ready", Interval: defaultRequeueInterval},
+		{Phase: "Cleanup", Interval: defaultRequeueInterval},
+		{Phase: "Teardown", Interval: defaultRequeueInterval},
+	}
+
+	tests := []struct {
+		name       string
+		controller *ControllerSpec
+		want       []*RequeueRule
+		wantErr    bool
+	}{
+		{
+			name:       "no controller config",
+			controller: nil,
+			want:       defaults,
+		},
+		{
+			name: "override default phase and add phase",
+			controller: &ControllerSpec{
+				Requeue: []*RequeuePolicy{
+					{Phase: "Check-Ready", Interval: "2s", MaxInterval: "2m", MaxRetries: 30},
+					{Phase: "Create-Resources"},
+				},
+			},
+			want: []*RequeueRule{
+				{Phase: "Dependency", Interval: defaultRequeueInterval},
+				{Phase: "Check-Ready", Interval: 2 * time.Second, MaxInterval: 2 * time.Minute, MaxRetries: 30},
+				{Phase: "Cleanup", Interval: defaultRequeueInterval},
+				{Phase: "Teardown", Interval: defaultRequeueInterval},
+				{Phase: "Create-Resources", Interval: defaultRequeueInterval},
+			},
+		},
+		{
+			name:       "missing phase",
+			controller: &ControllerSpec{Requeue: []*RequeuePolicy{{Interval: "2s"}}},
+			wantErr:    true,
+		},
+		{
+			name: "duplicate phase",
+			controller: &ControllerSpec{
+				Requeue: []*RequeuePolicy{{Phase: "Dependency"}, {Phase: "Dependency"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "invalid interval",
+			controller: &ControllerSpec{Requeue: []*RequeuePolicy{{Phase: "Dependency", Interval: "soon"}}},
+			wantErr:    true,
+		},
+		{
+			name:       "max interval less than interval",
+			controller: &ControllerSpec{Requeue: []*RequeuePolicy{{Phase: "Dependency", Interval: "1m", MaxInterval: "10s"}}},
+			wantErr:    true,
+		},
+		{
+			name:       "negative max retries",
+			controller: &ControllerSpec{Requeue: []*RequeuePolicy{{Phase: "Dependency", MaxRetries: -1}}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{Controller: tt.controller}
+
+			err := ws.processRequeuePolicies()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRequeuePolicy)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ws.RequeueRules)
+		})
+	}
+}
+
+func Test_durationCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 0, want: "0"},
+		{duration: 5 * time.Second, want: "5 * time.Second"},
+		{duration: 90 * time.Second, want: "90 * time.Second"},
+		{duration: 2 * time.Hour, want: "2 * time.Hour"},
+		{duration: 1500 * time.Millisecond, want: "1500 * time.Millisecond"},
+		{duration: 3, want: "3 * time.Nanosecond"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, durationCode(tt.duration))
+		})
+	}
+}
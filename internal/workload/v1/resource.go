@@ -29,10 +29,12 @@ type ChildResource struct {
 	UniqueName    string
 	Group         string
 	Version       string
-	Kind          string
-	StaticContent string
-	SourceCode    string
-	IncludeCode   string
+	Kind           string
+	ClusterScoped  bool
+	NamespaceField bool
+	StaticContent  string
+	SourceCode     string
+	IncludeCode    string
 }
 
 // Resource represents a single input manifest for a given config.
@@ -34,8 +34,9 @@ func NewStandaloneWorkload(
 ) *StandaloneWorkload {
 	return &StandaloneWorkload{
 		WorkloadShared: WorkloadShared{
-			Kind: WorkloadKindStandalone,
-			Name: name,
+			APIVersion: WorkloadConfigLatestVersion,
+			Kind:       WorkloadKindStandalone,
+			Name:       name,
 		},
 		Spec: StandaloneWorkloadSpec{
 			API: spec,
@@ -120,10 +121,26 @@ func (s *StandaloneWorkload) GetAPIKind() string {
 	return s.Spec.API.Kind
 }
 
+func (s *StandaloneWorkload) GetAPIShortNames() []string {
+	return s.Spec.API.ShortNames
+}
+
+func (s *StandaloneWorkload) GetAPICategories() []string {
+	return s.Spec.API.Categories
+}
+
 func (s *StandaloneWorkload) IsClusterScoped() bool {
 	return s.Spec.API.ClusterScoped
 }
 
+func (s *StandaloneWorkload) HasWebhooks() bool {
+	return s.Spec.API.Webhooks
+}
+
+func (s *StandaloneWorkload) HasPausedField() bool {
+	return s.Spec.API.PausedField
+}
+
 func (*StandaloneWorkload) IsStandalone() bool {
 	return true
 }
@@ -137,11 +154,15 @@ func (*StandaloneWorkload) IsCollection() bool {
 }
 
 func (s *StandaloneWorkload) SetResources(workloadPath string) error {
-	err := s.Spec.processManifests(FieldMarkerType)
+	err := s.Spec.processManifests(FieldMarkerType, ValidationMarkerType)
 	if err != nil {
 		return err
 	}
 
+	if s.HasPausedField() {
+		return s.Spec.appendPausedField()
+	}
+
 	return nil
 }
 
@@ -178,6 +199,34 @@ func (s *StandaloneWorkload) GetAPISpecFields() *APIFields {
 	return s.Spec.APISpecFields
 }
 
+func (s *StandaloneWorkload) GetStatusFields() []*StatusField {
+	return s.Spec.StatusFields
+}
+
+func (s *StandaloneWorkload) GetReadyKinds() []string {
+	return s.Spec.ReadyKinds
+}
+
+func (s *StandaloneWorkload) GetReadyConditions() []*ReadyCondition {
+	return s.Spec.ReadyConditions
+}
+
+func (s *StandaloneWorkload) GetDeleteWaves() []*DeleteWave {
+	return s.Spec.DeleteWaves
+}
+
+func (s *StandaloneWorkload) GetDriftRules() []*DriftRule {
+	return s.Spec.DriftRules
+}
+
+func (s *StandaloneWorkload) GetRequeueRules() []*RequeueRule {
+	return s.Spec.RequeueRules
+}
+
+func (s *StandaloneWorkload) GetControllerOptions() *ControllerOptions {
+	return s.Spec.ControllerOptions
+}
+
 func (s *StandaloneWorkload) GetRBACRules() *[]RBACRule {
 	var rules []RBACRule = *s.Spec.RBACRules
 
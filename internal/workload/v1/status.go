@@ -0,0 +1,177 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidStatusField   = errors.New("status marker field must be a lower camel case name")
+	ErrReservedStatusField  = errors.New("status marker field is reserved for internal purposes")
+	ErrDuplicateStatusField = errors.New("status marker field is used by more than one status marker")
+	ErrInvalidStatusPath    = errors.New("status marker path must be made up of field names and array indexes")
+)
+
+var (
+	// statusFieldName matches the name of a status field, which is a single field of the
+	// status rather than a path.
+	statusFieldName = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+
+	// statusPath matches the path of a value of a resource, e.g. .status.loadBalancer.ingress[0].ip,
+	// and statusPathPart matches each field name or array index of the path.
+	statusPath     = regexp.MustCompile(`^(?:\.[A-Za-z_][A-Za-z0-9_-]*(?:\[[0-9]+\])*)+$`)
+	statusPathPart = regexp.MustCompile(`\.([A-Za-z_][A-Za-z0-9_-]*)|\[([0-9]+)\]`)
+)
+
+// StatusField is a field of the status of a workload which is copied from the value of
+// a child resource as it exists in the cluster.
+type StatusField struct {
+	Name       string
+	Type       FieldType
+	Path       string
+	Comments   []string
+	CreateFunc string
+}
+
+// FieldName returns the name of the status field in the generated status type.
+func (sf *StatusField) FieldName() string {
+	return strings.Title(sf.Name)
+}
+
+// PathArgs returns the field names and array indexes of the path to the value of the
+// child resource as a list of arguments for the generated code.
+func (sf *StatusField) PathArgs() string {
+	args := []string{}
+
+	for _, match := range statusPathPart.FindAllStringSubmatch(sf.Path, -1) {
+		if match[1] != "" {
+			args = append(args, strconv.Quote(match[1]))
+		} else {
+			args = append(args, match[2])
+		}
+	}
+
+	return strings.Join(args, ", ")
+}
+
+func reservedStatusFields() []string {
+	return []string{
+		"created",
+		"dependenciesSatisfied",
+		"observedGeneration",
+		"conditions",
+		"phaseConditions",
+		"resources",
+	}
+}
+
+// processStatusMarkers adds a status field for each status marker of the manifest of a
+// child resource and returns the manifest with the markers transformed.
+func (ws *WorkloadSpec) processStatusMarkers(manifest string, child *ChildResource) (string, error) {
+	nodes, markerResults, err := inspectMarkersForYAML([]byte(manifest), StatusMarkerType)
+	if err != nil {
+		return "", err
+	}
+
+	if len(markerResults) == 0 {
+		return manifest, nil
+	}
+
+	for _, markerResult := range markerResults {
+		sm, ok := markerResult.Object.(StatusMarker)
+		if !ok {
+			continue
+		}
+
+		if err := ws.addStatusField(&sm, child); err != nil {
+			return "", err
+		}
+	}
+
+	return marshalManifest(nodes, child)
+}
+
+// marshalManifest returns the manifest of a child resource from its nodes once the markers
+// of the manifest have been transformed.
+func marshalManifest(nodes []*yaml.Node, child *ChildResource) (string, error) {
+	buf := bytes.Buffer{}
+
+	for _, node := range nodes {
+		m, err := yaml.Marshal(node)
+		if err != nil {
+			return "", fmt.Errorf("%w; error marshaling manifest for %s", err, child.Name)
+		}
+
+		mustWrite(buf.WriteString("---\n"))
+		mustWrite(buf.Write(m))
+	}
+
+	return buf.String(), nil
+}
+
+// addStatusField adds the status field of a status marker, which copies the value from
+// the child resource, after checking that the field and path are valid.
+func (ws *WorkloadSpec) addStatusField(sm *StatusMarker, child *ChildResource) error {
+	if !statusFieldName.MatchString(sm.Field) {
+		return fmt.Errorf("%w; %s for marker %s", ErrInvalidStatusField, sm.Field, sm)
+	}
+
+	for _, reserved := range reservedStatusFields() {
+		if sm.Field == reserved {
+			return fmt.Errorf("%w; %s for marker %s", ErrReservedStatusField, sm.Field, sm)
+		}
+	}
+
+	for _, existing := range ws.StatusFields {
+		if sm.Field == existing.Name {
+			return fmt.Errorf("%w; %s for marker %s", ErrDuplicateStatusField, sm.Field, sm)
+		}
+	}
+
+	path := sm.Path
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+
+	if !statusPath.MatchString(path) {
+		return fmt.Errorf("%w; %s for marker %s", ErrInvalidStatusPath, sm.Path, sm)
+	}
+
+	field := &StatusField{
+		Name:       sm.Field,
+		Type:       FieldString,
+		Path:       path,
+		CreateFunc: "Create" + child.UniqueName,
+	}
+
+	if sm.Type != FieldUnknownType {
+		field.Type = sm.Type
+	}
+
+	if sm.Description != nil {
+		field.Comments = strings.Split(strings.TrimPrefix(*sm.Description, "\n"), "\n")
+	} else {
+		// the name of the child resource is omitted when it is controlled by a field
+		resource := child.Kind
+		if !strings.Contains(child.Name, "!!") {
+			resource = fmt.Sprintf("%s %s", child.Name, child.Kind)
+		}
+
+		field.Comments = []string{
+			fmt.Sprintf("%s is copied from %s of the %s.", field.FieldName(), path, resource),
+		}
+	}
+
+	ws.StatusFields = append(ws.StatusFields, field)
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadSpec_addStatusField(t *testing.T) {
+	t.Parallel()
+
+	child := &ChildResource{Name: "webstore-svc", Kind: "Service", UniqueName: "ServiceWebstoreSvc"}
+
+	tests := []struct {
+		name    string
+		marker  *StatusMarker
+		want    *StatusField
+		wantErr error
+	}{
+		{
+			name:   "string field",
+			marker: &StatusMarker{Field: "endpoint", Path: ".status.loadBalancer.ingress[0].ip"},
+			want: &StatusField{
+				Name:       "endpoint",
+				Type:       FieldString,
+				Path:       ".status.loadBalancer.ingress[0].ip",
+				Comments:   []string{"Endpoint is copied from .status.loadBalancer.ingress[0].ip of the webstore-svc Service."},
+				CreateFunc: "CreateServiceWebstoreSvc",
+			},
+		},
+		{
+			name:   "int field without leading dot",
+			marker: &StatusMarker{Field: "nodePort", Path: "spec.ports[0].nodePort", Type: FieldInt},
+			want: &StatusField{
+				Name:       "nodePort",
+				Type:       FieldInt,
+				Path:       ".spec.ports[0].nodePort",
+				Comments:   []string{"NodePort is copied from .spec.ports[0].nodePort of the webstore-svc Service."},
+				CreateFunc: "CreateServiceWebstoreSvc",
+			},
+		},
+		{
+			name:    "nested field",
+			marker:  &StatusMarker{Field: "service.endpoint", Path: ".status.loadBalancer.ingress[0].ip"},
+			wantErr: ErrInvalidStatusField,
+		},
+		{
+			name:    "reserved field",
+			marker:  &StatusMarker{Field: "conditions", Path: ".status.conditions"},
+			wantErr: ErrReservedStatusField,
+		},
+		{
+			name:    "duplicate field",
+			marker:  &StatusMarker{Field: "clusterIP", Path: ".spec.clusterIP"},
+			wantErr: ErrDuplicateStatusField,
+		},
+		{
+			name:    "filter path",
+			marker:  &StatusMarker{Field: "ready", Path: `.status.conditions[?(@.type=="Ready")].status`},
+			wantErr: ErrInvalidStatusPath,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ws := &WorkloadSpec{}
+			require.NoError(t, ws.addStatusField(&StatusMarker{Field: "clusterIP", Path: ".spec.clusterIP"}, child))
+
+			err := ws.addStatusField(tt.marker, child)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, ws.StatusFields, 2)
+			assert.Equal(t, tt.want, ws.StatusFields[1])
+		})
+	}
+}
+
+func TestStatusField_PathArgs(t *testing.T) {
+	t.Parallel()
+
+	field := &StatusField{Path: ".status.loadBalancer.ingress[0].ip"}
+
+	assert.Equal(t, `"status", "loadBalancer", "ingress", 0, "ip"`, field.PathArgs())
+}
+
+func TestWorkloadSpec_processStatusMarkers(t *testing.T) {
+	t.Parallel()
+
+	manifest := `
+# +operator-builder:status:field=endpoint,path=".status.loadBalancer.ingress[0].ip"
+apiVersion: v1
+kind: Service
+metadata:
+  name: webstore-svc
+`
+
+	ws := &WorkloadSpec{}
+
+	got, err := ws.processStatusMarkers(manifest, &ChildResource{Name: "webstore-svc", Kind: "Service"})
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "# copied to status field: endpoint")
+	assert.NotContains(t, got, "+operator-builder:status")
+	require.Len(t, ws.StatusFields, 1)
+	assert.Equal(t, "endpoint", ws.StatusFields[0].Name)
+}
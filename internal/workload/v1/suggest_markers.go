@@ -0,0 +1,547 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu-labs/operator-builder/internal/utils"
+)
+
+var ErrSuggestMarkersOverwrite = errors.New("output directory must differ from the manifests directory")
+
+const (
+	markerPrefix   = "+operator-builder:"
+	pathWildcard   = "*"
+	outputDirPerms = 0755
+	imageTagField  = "image-tag"
+	imageField     = "image"
+	namespaceField = "namespace"
+	storageField   = "storage-size"
+	hostField      = "host"
+	replicasField  = "replicas"
+	itemNameKey    = "name"
+	metadataKey    = "metadata"
+)
+
+// SuggestMarkersOptions defines the inputs used to suggest field markers for a
+// directory of manifests.
+type SuggestMarkersOptions struct {
+	// ManifestsPath is the directory which is scanned for manifests.
+	ManifestsPath string
+
+	// OutputPath is the directory that annotated copies of the manifests are
+	// written to.  The directory structure of the manifests is preserved.
+	OutputPath string
+
+	// Force overwrites existing files in the output directory.
+	Force bool
+}
+
+// MarkerSuggestion describes a field marker which was suggested for a value within a
+// manifest.
+type MarkerSuggestion struct {
+	File   string
+	Object string
+	Path   string
+	Field  string
+	Marker string
+}
+
+// markerSuggestionRule defines a commonly parameterized path within a kubernetes
+// object for which a field marker is suggested.
+type markerSuggestionRule struct {
+	// kinds limits the rule to specific object kinds.  An empty list matches all kinds.
+	kinds []string
+
+	// path is the dot separated path to the value.  A wildcard segment matches each
+	// item of a sequence.
+	path string
+
+	// field is the kebab-case name of the field, which is prefixed with the name of the
+	// object or sequence item unless the field is shared.
+	field string
+
+	fieldType   FieldType
+	description string
+
+	// shared fields are named without a prefix so that a single field controls the
+	// value across all objects, e.g. the namespace.
+	shared bool
+
+	// image suggests a field for only the tag of a container image when the image is
+	// tagged.
+	image bool
+}
+
+// fieldSuggestion is a suggested field marker for a single value.
+type fieldSuggestion struct {
+	field       string
+	name        string
+	fieldType   FieldType
+	defaultVal  string
+	replace     string
+	description string
+}
+
+// fieldNamer assigns names to suggested fields, reusing a name only when the type and
+// default value of the fields are identical.
+type fieldNamer struct {
+	claimed map[string]string
+}
+
+// SuggestMarkers scans a directory of manifests and writes copies of them, annotated
+// with suggested field markers for commonly parameterized values, to the output
+// directory.  Values which already have a marker are left untouched.
+func SuggestMarkers(options *SuggestMarkersOptions) ([]*MarkerSuggestion, error) {
+	outputPath, err := filepath.Abs(options.OutputPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine absolute file path, %w", err)
+	}
+
+	manifestsPath, err := filepath.Abs(options.ManifestsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine absolute file path, %w", err)
+	}
+
+	if outputPath == manifestsPath {
+		return nil, fmt.Errorf("%w; %s", ErrSuggestMarkersOverwrite, options.OutputPath)
+	}
+
+	manifests, err := scanManifests(manifestsPath, manifestsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	namer := &fieldNamer{claimed: map[string]string{}}
+
+	var suggestions []*MarkerSuggestion
+
+	for _, manifest := range manifests {
+		source := filepath.Join(manifestsPath, manifest.path)
+
+		// skip the output of a previous run when it is nested within the manifests
+		if strings.HasPrefix(source, outputPath+string(filepath.Separator)) {
+			continue
+		}
+
+		fileSuggestions, err := suggestFileMarkers(source, filepath.Join(outputPath, manifest.path), options.Force, namer)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, suggestion := range fileSuggestions {
+			suggestion.File = manifest.path
+		}
+
+		suggestions = append(suggestions, fileSuggestions...)
+	}
+
+	return suggestions, nil
+}
+
+// suggestFileMarkers annotates the objects within a single manifest file and writes
+// the annotated copy to the destination.
+func suggestFileMarkers(source, destination string, force bool, namer *fieldNamer) ([]*MarkerSuggestion, error) {
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest %s, %w", source, err)
+	}
+
+	documents, err := decodeConfigNodes(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode manifest %s, %w", source, err)
+	}
+
+	var suggestions []*MarkerSuggestion
+
+	for _, document := range documents {
+		mapping := documentMapping(document)
+		if mapping == nil {
+			continue
+		}
+
+		suggestions = append(suggestions, suggestObjectMarkers(mapping, namer)...)
+	}
+
+	data, err := encodeConfigNodes(documents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode manifest %s, %w", source, err)
+	}
+
+	if _, err := os.Stat(destination); err == nil && !force {
+		return nil, fmt.Errorf("%w at location %s", ErrFileExists, destination)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), outputDirPerms); err != nil {
+		return nil, fmt.Errorf("unable to create directory %s, %w", filepath.Dir(destination), err)
+	}
+
+	if err := os.WriteFile(destination, data, permissions); err != nil {
+		return nil, fmt.Errorf("%w; %s at location %s", err, ErrWriteFile, destination)
+	}
+
+	return suggestions, nil
+}
+
+// suggestObjectMarkers applies each suggestion rule to a single kubernetes object,
+// inserting the suggested markers as comments on the matching values.
+func suggestObjectMarkers(object *yaml.Node, namer *fieldNamer) []*MarkerSuggestion {
+	kind := scalarValue(mappingValue(object, kindKey))
+	if kind == "" || isConfigAPIVersion(scalarValue(mappingValue(object, apiVersionKey))) {
+		return nil
+	}
+
+	objectName := strings.ToLower(kind)
+
+	if metadata := mappingValue(object, metadataKey); metadata != nil && metadata.Kind == yaml.MappingNode {
+		objectName = valueOrDefault(scalarValue(mappingValue(metadata, itemNameKey)), objectName)
+	}
+
+	var suggestions []*MarkerSuggestion
+
+	for _, rule := range markerSuggestionRules() {
+		if len(rule.kinds) > 0 && !containsString(rule.kinds, kind) {
+			continue
+		}
+
+		rule := rule
+
+		walkNodePath(object, object, strings.Split(rule.path, "."), "", "", func(key, value *yaml.Node, path, itemName string) {
+			if hasMarker(key, value) {
+				return
+			}
+
+			suggestion := rule.suggest(value.Value)
+			if suggestion == nil {
+				return
+			}
+
+			suggestion.name = namer.claim(rule.candidateNames(suggestion.field, objectName, itemName, value.Value), suggestion)
+
+			marker := suggestion.marker()
+
+			// keep any existing line comment in place by moving the marker above the key
+			if value.LineComment != "" {
+				key.HeadComment = strings.TrimPrefix(key.HeadComment+"\n# "+marker, "\n")
+			} else {
+				value.LineComment = "# " + marker
+			}
+
+			suggestions = append(suggestions, &MarkerSuggestion{
+				Object: fmt.Sprintf("%s/%s", kind, objectName),
+				Path:   path,
+				Field:  suggestion.name,
+				Marker: marker,
+			})
+		})
+	}
+
+	return suggestions
+}
+
+// markerSuggestionRules returns the rules for paths which are commonly parameterized.
+func markerSuggestionRules() []markerSuggestionRule {
+	rules := []markerSuggestionRule{
+		{
+			path:        "metadata.namespace",
+			field:       namespaceField,
+			fieldType:   FieldString,
+			description: "Namespace where the resources are deployed",
+			shared:      true,
+		},
+		{
+			kinds:       []string{"Namespace"},
+			path:        "metadata.name",
+			field:       namespaceField,
+			fieldType:   FieldString,
+			description: "Namespace where the resources are deployed",
+			shared:      true,
+		},
+		{
+			kinds:       []string{"Deployment", "StatefulSet", "ReplicaSet"},
+			path:        "spec.replicas",
+			field:       replicasField,
+			fieldType:   FieldInt,
+			description: "Number of replicas",
+		},
+		{
+			kinds:       []string{"Ingress"},
+			path:        "spec.rules.*.host",
+			field:       hostField,
+			fieldType:   FieldString,
+			description: "Hostname used to route traffic",
+		},
+		{
+			kinds:       []string{"Ingress"},
+			path:        "spec.tls.*.hosts.*",
+			field:       hostField,
+			fieldType:   FieldString,
+			description: "Hostname used to route traffic",
+		},
+		{
+			kinds:       []string{"HTTPProxy"},
+			path:        "spec.virtualhost.fqdn",
+			field:       hostField,
+			fieldType:   FieldString,
+			description: "Hostname used to route traffic",
+		},
+		{
+			kinds:       []string{"PersistentVolumeClaim"},
+			path:        "spec.resources.requests.storage",
+			field:       storageField,
+			fieldType:   FieldString,
+			description: "Size of the requested storage",
+		},
+		{
+			kinds:       []string{"StatefulSet"},
+			path:        "spec.volumeClaimTemplates.*.spec.resources.requests.storage",
+			field:       storageField,
+			fieldType:   FieldString,
+			description: "Size of the requested storage",
+		},
+	}
+
+	return append(rules, containerMarkerSuggestionRules()...)
+}
+
+// containerMarkerSuggestionRules returns the rules for the containers within each of
+// the pod specs of the common workload kinds.
+func containerMarkerSuggestionRules() []markerSuggestionRule {
+	podSpecs := []struct {
+		kinds []string
+		path  string
+	}{
+		{kinds: []string{"Pod"}, path: "spec"},
+		{kinds: []string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job"}, path: "spec.template.spec"},
+		{kinds: []string{"CronJob"}, path: "spec.jobTemplate.spec.template.spec"},
+	}
+
+	containerFields := []markerSuggestionRule{
+		{path: "image", field: imageTagField, fieldType: FieldString, description: "Image tag for the container", image: true},
+		{path: "resources.requests.cpu", field: "cpu-request", fieldType: FieldString, description: "CPU requested by the container"},
+		{path: "resources.requests.memory", field: "memory-request", fieldType: FieldString, description: "Memory requested by the container"},
+		{path: "resources.limits.cpu", field: "cpu-limit", fieldType: FieldString, description: "CPU limit of the container"},
+		{path: "resources.limits.memory", field: "memory-limit", fieldType: FieldString, description: "Memory limit of the container"},
+	}
+
+	var rules []markerSuggestionRule
+
+	for _, podSpec := range podSpecs {
+		for _, containers := range []string{"containers", "initContainers"} {
+			for _, containerField := range containerFields {
+				rule := containerField
+				rule.kinds = podSpec.kinds
+				rule.path = strings.Join([]string{podSpec.path, containers, pathWildcard, containerField.path}, ".")
+
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	return rules
+}
+
+// suggest returns the suggested field for a value, or nil if the value is not suitable
+// for the rule.
+func (rule *markerSuggestionRule) suggest(value string) *fieldSuggestion {
+	if value == "" {
+		return nil
+	}
+
+	suggestion := &fieldSuggestion{
+		field:       rule.field,
+		fieldType:   rule.fieldType,
+		defaultVal:  value,
+		description: rule.description,
+	}
+
+	if rule.fieldType == FieldInt {
+		if _, err := strconv.Atoi(value); err != nil {
+			return nil
+		}
+	}
+
+	if rule.image {
+		// only the tag of a tagged image is suggested as a field, as the repository is
+		// rarely changed; digests and untagged images are suggested as a whole
+		colon := strings.LastIndex(value, ":")
+		if strings.Contains(value, "@") || colon <= strings.LastIndex(value, "/") {
+			suggestion.field = imageField
+			suggestion.description = "Image for the container"
+
+			return suggestion
+		}
+
+		suggestion.defaultVal = value[colon+1:]
+		suggestion.replace = regexp.QuoteMeta(suggestion.defaultVal) + "$"
+	}
+
+	return suggestion
+}
+
+// candidateNames returns the names for a field in order of preference.  Shared fields
+// are qualified by their value when they conflict, while all other fields are qualified
+// by the names of the sequence item and object which contain them.
+func (rule *markerSuggestionRule) candidateNames(field, objectName, itemName, value string) []string {
+	if rule.shared {
+		return []string{
+			utils.ToCamelCase(field),
+			utils.ToCamelCase(proposeName(value) + "-" + field),
+		}
+	}
+
+	if itemName == "" {
+		return []string{utils.ToCamelCase(proposeName(objectName) + "-" + field)}
+	}
+
+	return []string{
+		utils.ToCamelCase(proposeName(itemName) + "-" + field),
+		utils.ToCamelCase(proposeName(objectName) + "-" + proposeName(itemName) + "-" + field),
+	}
+}
+
+// claim returns the first candidate name which is either unused or is used by a field
+// of the same type and default value.  If all candidates conflict, a numeric suffix is
+// added to the last candidate.
+func (namer *fieldNamer) claim(candidates []string, suggestion *fieldSuggestion) string {
+	identity := fmt.Sprintf("%s=%s", suggestion.fieldType, suggestion.defaultVal)
+
+	for _, candidate := range candidates {
+		if claimed, ok := namer.claimed[candidate]; !ok || claimed == identity {
+			namer.claimed[candidate] = identity
+
+			return candidate
+		}
+	}
+
+	last := candidates[len(candidates)-1]
+
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", last, i)
+
+		if claimed, ok := namer.claimed[candidate]; !ok || claimed == identity {
+			namer.claimed[candidate] = identity
+
+			return candidate
+		}
+	}
+}
+
+// marker returns the text of the field marker for the suggestion.
+func (suggestion *fieldSuggestion) marker() string {
+	defaultVal := strconv.Quote(suggestion.defaultVal)
+	if suggestion.fieldType == FieldInt {
+		defaultVal = suggestion.defaultVal
+	}
+
+	args := []string{
+		"name=" + suggestion.name,
+		"type=" + suggestion.fieldType.String(),
+		"default=" + defaultVal,
+	}
+
+	if suggestion.replace != "" {
+		args = append(args, "replace="+strconv.Quote(suggestion.replace))
+	}
+
+	if suggestion.description != "" {
+		args = append(args, "description="+strconv.Quote(suggestion.description))
+	}
+
+	return fmt.Sprintf("%s:%s", fieldMarker, strings.Join(args, ","))
+}
+
+// walkNodePath calls fn for each scalar value found at the path within a yaml node.  The
+// name of the most recent named sequence item, such as a container, is tracked so that
+// it may be used to name the field.
+func walkNodePath(
+	key, node *yaml.Node,
+	path []string,
+	walked, itemName string,
+	fn func(key, value *yaml.Node, path, itemName string),
+) {
+	if len(path) == 0 {
+		if node.Kind == yaml.ScalarNode {
+			fn(key, node, walked, itemName)
+		}
+
+		return
+	}
+
+	if path[0] == pathWildcard {
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for i, item := range node.Content {
+			walkNodePath(item, item, path[1:], fmt.Sprintf("%s[%d]", walked, i), sequenceItemName(item, itemName), fn)
+		}
+
+		return
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != path[0] {
+			continue
+		}
+
+		next := path[0]
+		if walked != "" {
+			next = walked + "." + path[0]
+		}
+
+		walkNodePath(node.Content[i], node.Content[i+1], path[1:], next, itemName, fn)
+
+		return
+	}
+}
+
+// sequenceItemName returns the name of a sequence item from either its name or its
+// metadata.name field, falling back to the name of the enclosing item.
+func sequenceItemName(item *yaml.Node, fallback string) string {
+	if item.Kind != yaml.MappingNode {
+		return fallback
+	}
+
+	if name := scalarValue(mappingValue(item, itemNameKey)); name != "" {
+		return name
+	}
+
+	if metadata := mappingValue(item, metadataKey); metadata != nil && metadata.Kind == yaml.MappingNode {
+		return valueOrDefault(scalarValue(mappingValue(metadata, itemNameKey)), fallback)
+	}
+
+	return fallback
+}
+
+func hasMarker(key, value *yaml.Node) bool {
+	for _, comment := range []string{key.HeadComment, key.LineComment, value.HeadComment, value.LineComment} {
+		if strings.Contains(comment, markerPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
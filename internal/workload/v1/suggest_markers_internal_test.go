@@ -0,0 +1,168 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_suggestObjectMarkers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+	}{
+		{
+			name: "deployment suggests replicas, image tag and resources",
+			input: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webstore
+  namespace: shop
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: nginx
+          image: nginx:1.17
+          resources:
+            limits:
+              memory: 128Mi
+`,
+			expected: map[string]string{
+				"metadata.namespace":                                       "namespace",
+				"spec.replicas":                                            "webstoreReplicas",
+				"spec.template.spec.containers[0].image":                   "nginxImageTag",
+				"spec.template.spec.containers[0].resources.limits.memory": "nginxMemoryLimit",
+			},
+		},
+		{
+			name: "existing markers are not replaced",
+			input: `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  namespace: shop # +operator-builder:field:name=ns,type=string
+spec:
+  resources:
+    requests:
+      storage: 1Gi
+`,
+			expected: map[string]string{
+				"spec.resources.requests.storage": "dataStorageSize",
+			},
+		},
+		{
+			name: "workload configs are ignored",
+			input: `apiVersion: workloads.operatorbuilder.io/v1
+kind: StandaloneWorkload
+metadata:
+  namespace: shop
+`,
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			documents, err := decodeConfigNodes([]byte(tt.input))
+			require.NoError(t, err)
+			require.Len(t, documents, 1)
+
+			suggestions := suggestObjectMarkers(documentMapping(documents[0]), &fieldNamer{claimed: map[string]string{}})
+
+			actual := map[string]string{}
+			for _, suggestion := range suggestions {
+				actual[suggestion.Path] = suggestion.Field
+			}
+
+			assert.Equal(t, tt.expected, actual)
+
+			// the suggested markers must be understood by the marker inspector
+			content, err := encodeConfigNodes(documents)
+			require.NoError(t, err)
+
+			_, results, err := inspectMarkersForYAML(content, FieldMarkerType)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, len(results), len(suggestions))
+		})
+	}
+}
+
+func Test_markerSuggestionRule_suggest_image(t *testing.T) {
+	t.Parallel()
+
+	rule := &markerSuggestionRule{field: imageTagField, fieldType: FieldString, image: true}
+
+	tests := []struct {
+		name         string
+		image        string
+		expectedTag  string
+		expectedFull string
+	}{
+		{
+			name:         "tagged image replaces the tag",
+			image:        "nginx:1.17",
+			expectedTag:  "1.17",
+			expectedFull: "nginx:tag",
+		},
+		{
+			name:         "regex characters in the tag are matched literally",
+			image:        "registry:5000/nginx:1.2.3+build",
+			expectedTag:  "1.2.3+build",
+			expectedFull: "registry:5000/nginx:tag",
+		},
+		{
+			name:         "digest suggests the whole image",
+			image:        "nginx@sha256:0123abcd",
+			expectedTag:  "nginx@sha256:0123abcd",
+			expectedFull: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			suggestion := rule.suggest(tt.image)
+			require.NotNil(t, suggestion)
+			assert.Equal(t, tt.expectedTag, suggestion.defaultVal)
+
+			if tt.expectedFull == "" {
+				assert.Empty(t, suggestion.replace)
+
+				return
+			}
+
+			re, err := regexp.Compile(suggestion.replace)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFull, re.ReplaceAllString(tt.image, "tag"))
+		})
+	}
+}
+
+func Test_fieldNamer_claim(t *testing.T) {
+	t.Parallel()
+
+	namer := &fieldNamer{claimed: map[string]string{}}
+
+	shop := &fieldSuggestion{fieldType: FieldString, defaultVal: "shop"}
+	other := &fieldSuggestion{fieldType: FieldString, defaultVal: "other"}
+
+	assert.Equal(t, "namespace", namer.claim([]string{"namespace", "shopNamespace"}, shop))
+	assert.Equal(t, "namespace", namer.claim([]string{"namespace", "shopNamespace"}, shop))
+	assert.Equal(t, "otherNamespace", namer.claim([]string{"namespace", "otherNamespace"}, other))
+	assert.Equal(t, "otherNamespace2", namer.claim([]string{"otherNamespace"}, shop))
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrMissingValidationRule      = errors.New("validation marker is missing a rule")
+	ErrInvalidValidationField     = errors.New("validation marker field does not exist in the api spec")
+	ErrInvalidValidationReference = errors.New("validation marker rule references a field which does not exist in the api spec")
+)
+
+// celReference matches each path referenced from self, or oldSelf, in a CEL rule.
+var celReference = regexp.MustCompile(`\b(?:self|oldSelf)((?:\.[A-Za-z_][A-Za-z0-9_]*)+)`)
+
+// addValidation adds the CEL rule of a validation marker to the api field it applies to,
+// after checking that each field referenced by the rule exists.
+func (api *APIFields) addValidation(vm *ValidationMarker) error {
+	if vm.Rule == "" {
+		return fmt.Errorf("%w for marker %s", ErrMissingValidationRule, vm)
+	}
+
+	target := api
+
+	if vm.Field != nil && *vm.Field != "" {
+		fields := api.findField(*vm.Field)
+		if fields == nil {
+			return fmt.Errorf("%w; %s for marker %s", ErrInvalidValidationField, *vm.Field, vm)
+		}
+
+		target = fields[len(fields)-1]
+	}
+
+	if err := target.checkReferences(vm.Rule); err != nil {
+		return fmt.Errorf("%w for marker %s", err, vm)
+	}
+
+	validation := fmt.Sprintf("+kubebuilder:validation:XValidation:rule=%q", vm.Rule)
+	if vm.Message != nil && *vm.Message != "" {
+		validation = fmt.Sprintf("%s,message=%q", validation, *vm.Message)
+	}
+
+	target.Validations = append(target.Validations, validation)
+
+	return nil
+}
+
+// checkReferences ensures that each path referenced from self in a CEL rule exists beneath
+// the api field the rule applies to.  Once a path reaches a field other than a struct, the
+// remainder of the path is a function of the field's value and is not checked.
+func (api *APIFields) checkReferences(rule string) error {
+	for _, match := range celReference.FindAllStringSubmatch(rule, -1) {
+		obj := api
+
+		for _, part := range strings.Split(strings.TrimPrefix(match[1], "."), ".") {
+			if obj.Type != FieldStruct {
+				break
+			}
+
+			var found *APIFields
+
+			for _, child := range obj.Children {
+				if child.manifestName == part {
+					found = child
+
+					break
+				}
+			}
+
+			if found == nil {
+				return fmt.Errorf("%w; %s", ErrInvalidValidationReference, match[0])
+			}
+
+			obj = found
+		}
+	}
+
+	return nil
+}
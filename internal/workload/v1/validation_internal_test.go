@@ -0,0 +1,159 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidationTestFields(t *testing.T) *APIFields {
+	t.Helper()
+
+	api := &APIFields{Name: "Spec", Type: FieldStruct}
+	require.NoError(t, api.AddField("scaling.minReplicas", FieldInt, nil, 1, true))
+	require.NoError(t, api.AddField("scaling.maxReplicas", FieldInt, nil, 3, true))
+	require.NoError(t, api.AddField("mode", FieldString, nil, "web", false))
+
+	return api
+}
+
+func TestAPIFields_addValidation(t *testing.T) {
+	t.Parallel()
+
+	stringPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		marker  *ValidationMarker
+		path    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "spec rule",
+			marker: &ValidationMarker{
+				Rule:    "self.mode != 'worker' || self.scaling.maxReplicas == 1",
+				Message: stringPtr("workers may not be scaled"),
+			},
+			want: `+kubebuilder:validation:XValidation:rule="self.mode != 'worker' || self.scaling.maxReplicas == 1",` +
+				`message="workers may not be scaled"`,
+		},
+		{
+			name: "field rule",
+			marker: &ValidationMarker{
+				Rule:  "self.minReplicas <= self.maxReplicas",
+				Field: stringPtr("scaling"),
+			},
+			path: "scaling",
+			want: `+kubebuilder:validation:XValidation:rule="self.minReplicas <= self.maxReplicas"`,
+		},
+		{
+			name: "function of a field value",
+			marker: &ValidationMarker{
+				Rule:  "self.startsWith('web') && oldSelf.size() > 0",
+				Field: stringPtr("mode"),
+			},
+			path: "mode",
+			want: `+kubebuilder:validation:XValidation:rule="self.startsWith('web') && oldSelf.size() > 0"`,
+		},
+		{
+			name:    "missing rule",
+			marker:  &ValidationMarker{},
+			wantErr: ErrMissingValidationRule,
+		},
+		{
+			name: "missing field",
+			marker: &ValidationMarker{
+				Rule:  "self > 0",
+				Field: stringPtr("replicas"),
+			},
+			wantErr: ErrInvalidValidationField,
+		},
+		{
+			name: "missing reference",
+			marker: &ValidationMarker{
+				Rule: "self.scaling.replicas <= self.scaling.maxReplicas",
+			},
+			wantErr: ErrInvalidValidationReference,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newValidationTestFields(t)
+
+			err := api.addValidation(tt.marker)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			target := api
+			if tt.path != "" {
+				fields := api.findField(tt.path)
+				require.NotNil(t, fields)
+
+				target = fields[len(fields)-1]
+			}
+
+			assert.Equal(t, []string{tt.want}, target.Validations)
+		})
+	}
+}
+
+func TestAPIFields_GenerateAPISpec_Validations(t *testing.T) {
+	t.Parallel()
+
+	api := newValidationTestFields(t)
+
+	require.NoError(t, api.addValidation(&ValidationMarker{Rule: "self.mode != ''"}))
+	require.NoError(t, api.addValidation(&ValidationMarker{
+		Rule:  "self.minReplicas <= self.maxReplicas",
+		Field: func(s string) *string { return &s }("scaling"),
+	}))
+
+	spec := api.GenerateAPISpec("WebApp")
+
+	assert.Contains(t, spec, "// WebAppSpec defines the desired state of WebApp.\n"+
+		"// +kubebuilder:validation:XValidation:rule=\"self.mode != ''\"\ntype WebAppSpec struct {")
+	assert.Contains(t, spec, "// +kubebuilder:validation:XValidation:rule=\"self.minReplicas <= self.maxReplicas\"\n"+
+		"Scaling WebAppSpecScaling")
+	assert.Equal(t, 1, strings.Count(spec, "self.minReplicas <= self.maxReplicas"))
+}
+
+func TestValidationMarker_Inspect(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`---
+# +operator-builder:validation:rule="self.mode != 'worker' || self.replicas == 1",message="workers may not be scaled"
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webapp
+spec:
+  replicas: 1
+`)
+
+	_, results, err := inspectMarkersForYAML(content, ValidationMarkerType)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	vm, ok := results[0].Object.(ValidationMarker)
+	require.True(t, ok)
+
+	assert.Equal(t, "self.mode != 'worker' || self.replicas == 1", vm.Rule)
+	require.NotNil(t, vm.Message)
+	assert.Equal(t, "workers may not be scaled", *vm.Message)
+	assert.Nil(t, vm.Field)
+}
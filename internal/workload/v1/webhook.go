@@ -0,0 +1,273 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidFieldReference = errors.New("field marker references an invalid field")
+	ErrInvalidRuleValue      = errors.New("field marker rule value does not match the type of the referenced field")
+)
+
+// setRules sets the cross-field validation rules of a field marker on the api field at
+// path.  Rules are validated once all markers have been processed as they may refer to
+// fields which have not yet been added.
+func (api *APIFields) setRules(path string, requiredIf, mutuallyExclusive *string) {
+	fields := api.findField(path)
+	if fields == nil {
+		return
+	}
+
+	field := fields[len(fields)-1]
+
+	if requiredIf != nil {
+		field.RequiredIf = *requiredIf
+	}
+
+	if mutuallyExclusive != nil {
+		field.MutuallyExclusive = *mutuallyExclusive
+	}
+}
+
+// validateRules ensures that the cross-field validation rules of each api field refer
+// to an existing field and, for a requiredIf rule with a value, that the value can be
+// compared with the referenced field.
+func (api *APIFields) validateRules(root *APIFields) error {
+	for _, child := range api.Children {
+		if err := child.validateRules(root); err != nil {
+			return err
+		}
+	}
+
+	if api.RequiredIf != "" {
+		ref, value, hasValue := parseRule(api.RequiredIf)
+
+		refField, err := root.ruleReference(ref)
+		if err != nil {
+			return fmt.Errorf("%w; requiredIf for field %s", err, api.manifestName)
+		}
+
+		if hasValue {
+			if err := validateRuleValue(refField.Type, value); err != nil {
+				return fmt.Errorf("%w; requiredIf for field %s", err, api.manifestName)
+			}
+		}
+	}
+
+	if api.MutuallyExclusive != "" {
+		if _, err := root.ruleReference(api.MutuallyExclusive); err != nil {
+			return fmt.Errorf("%w; mutuallyExclusive for field %s", err, api.manifestName)
+		}
+	}
+
+	return nil
+}
+
+// ruleReference returns the field referenced by a rule, which must be a field other
+// than a struct.
+func (api *APIFields) ruleReference(path string) (*APIFields, error) {
+	fields := api.findField(path)
+	if fields == nil || fields[len(fields)-1].Type == FieldStruct {
+		return nil, fmt.Errorf("%w %s", ErrInvalidFieldReference, path)
+	}
+
+	return fields[len(fields)-1], nil
+}
+
+// GenerateValidation generates the statements which validate the cross-field rules of
+// the object named by receiver, appending any failures to a field.ErrorList named errs.
+func (api *APIFields) GenerateValidation(receiver string) string {
+	var buf bytes.Buffer
+
+	api.generateValidation(&buf, receiver+".Spec", api, nil)
+
+	return buf.String()
+}
+
+func (api *APIFields) generateValidation(b io.StringWriter, parent string, root *APIFields, path []*APIFields) {
+	for _, child := range api.Children {
+		childPath := append(path[:len(path):len(path)], child)
+
+		if child.Type == FieldStruct {
+			child.generateValidation(b, parent, root, childPath)
+
+			continue
+		}
+
+		expr := goExpression(parent, childPath)
+		fieldPath := fieldPathExpression(childPath)
+
+		if child.RequiredIf != "" {
+			ref, value, hasValue := parseRule(child.RequiredIf)
+
+			if refPath := root.findField(ref); refPath != nil {
+				refField := refPath[len(refPath)-1]
+				refExpr := goExpression(parent, refPath)
+
+				condition := isSet(refExpr, refField.Type)
+				message := fmt.Sprintf("required when %s is set", manifestPath(refPath))
+
+				if hasValue {
+					literal := ruleLiteral(refField.Type, value)
+					condition = fmt.Sprintf("%s == %s", refExpr, literal)
+					message = fmt.Sprintf("required when %s is %s", manifestPath(refPath), literal)
+				}
+
+				mustWrite(b.WriteString(fmt.Sprintf(
+					"if %s && %s {\nerrs = append(errs, field.Required(%s, %q))\n}\n\n",
+					condition, isUnset(expr, child.Type), fieldPath, message,
+				)))
+			}
+		}
+
+		if child.MutuallyExclusive != "" {
+			if refPath := root.findField(child.MutuallyExclusive); refPath != nil {
+				refField := refPath[len(refPath)-1]
+
+				mustWrite(b.WriteString(fmt.Sprintf(
+					"if %s && %s {\nerrs = append(errs, field.Forbidden(%s, %q))\n}\n\n",
+					isSet(expr, child.Type),
+					isSet(goExpression(parent, refPath), refField.Type),
+					fieldPath,
+					fmt.Sprintf("may not be set when %s is set", manifestPath(refPath)),
+				)))
+			}
+		}
+	}
+}
+
+// HasValidationRules determines if any api field has a cross-field validation rule.
+func (api *APIFields) HasValidationRules() bool {
+	if api.RequiredIf != "" || api.MutuallyExclusive != "" {
+		return true
+	}
+
+	for _, child := range api.Children {
+		if child.HasValidationRules() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findField returns the api fields from the first child to the field at the dot-separated
+// marker path, or nil if no field exists at the path.
+func (api *APIFields) findField(path string) []*APIFields {
+	fields := []*APIFields{}
+
+	obj := api
+
+	for _, part := range strings.Split(path, ".") {
+		var found *APIFields
+
+		for _, child := range obj.Children {
+			if child.manifestName == part {
+				found = child
+
+				break
+			}
+		}
+
+		if found == nil {
+			return nil
+		}
+
+		fields = append(fields, found)
+		obj = found
+	}
+
+	return fields
+}
+
+func parseRule(rule string) (ref, value string, hasValue bool) {
+	parts := strings.SplitN(rule, "=", 2)
+	if len(parts) == 1 {
+		return parts[0], "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+func ruleLiteral(fieldType FieldType, value string) string {
+	if fieldType == FieldString {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+func validateRuleValue(fieldType FieldType, value string) error {
+	switch fieldType {
+	case FieldInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%w; %s is not an int", ErrInvalidRuleValue, value)
+		}
+	case FieldBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%w; %s is not a bool", ErrInvalidRuleValue, value)
+		}
+	}
+
+	return nil
+}
+
+func isSet(expr string, fieldType FieldType) string {
+	switch fieldType {
+	case FieldInt:
+		return fmt.Sprintf("%s != 0", expr)
+	case FieldBool:
+		return expr
+	default:
+		return fmt.Sprintf("%s != \"\"", expr)
+	}
+}
+
+func isUnset(expr string, fieldType FieldType) string {
+	switch fieldType {
+	case FieldInt:
+		return fmt.Sprintf("%s == 0", expr)
+	case FieldBool:
+		return "!" + expr
+	default:
+		return fmt.Sprintf("%s == \"\"", expr)
+	}
+}
+
+func goExpression(parent string, path []*APIFields) string {
+	names := make([]string, len(path))
+
+	for i, field := range path {
+		names[i] = field.Name
+	}
+
+	return fmt.Sprintf("%s.%s", parent, strings.Join(names, "."))
+}
+
+func manifestPath(path []*APIFields) string {
+	names := []string{"spec"}
+
+	for _, field := range path {
+		names = append(names, field.manifestName)
+	}
+
+	return strings.Join(names, ".")
+}
+
+func fieldPathExpression(path []*APIFields) string {
+	names := []string{`"spec"`}
+
+	for _, field := range path {
+		names = append(names, strconv.Quote(field.manifestName))
+	}
+
+	return fmt.Sprintf("field.NewPath(%s)", strings.Join(names, ", "))
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookTestFields(t *testing.T) *APIFields {
+	t.Helper()
+
+	api := &APIFields{}
+	require.NoError(t, api.AddField("replicas", FieldInt, nil, 2, true))
+	require.NoError(t, api.AddField("image.name", FieldString, nil, "nginx", true))
+	require.NoError(t, api.AddField("image.pullSecret", FieldString, nil, "", false))
+	require.NoError(t, api.AddField("tls.enabled", FieldBool, nil, true, true))
+	require.NoError(t, api.AddField("tls.secret", FieldString, nil, "", false))
+	require.NoError(t, api.AddField("tls.issuer", FieldString, nil, "", false))
+	require.NoError(t, api.AddField("mode", FieldString, nil, "", false))
+
+	return api
+}
+
+func TestAPIFields_GenerateValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		path              string
+		requiredIf        string
+		mutuallyExclusive string
+		want              string
+	}{
+		{
+			name:       "required if set",
+			path:       "tls.secret",
+			requiredIf: "tls.enabled",
+			want: "if component.Spec.Tls.Enabled && component.Spec.Tls.Secret == \"\" {\n" +
+				"errs = append(errs, field.Required(field.NewPath(\"spec\", \"tls\", \"secret\"), " +
+				"\"required when spec.tls.enabled is set\"))\n}\n\n",
+		},
+		{
+			name:       "required if value",
+			path:       "image.pullSecret",
+			requiredIf: "mode=private",
+			want: "if component.Spec.Mode == \"private\" && component.Spec.Image.PullSecret == \"\" {\n" +
+				"errs = append(errs, field.Required(field.NewPath(\"spec\", \"image\", \"pullSecret\"), " +
+				"\"required when spec.mode is \\\"private\\\"\"))\n}\n\n",
+		},
+		{
+			name:              "mutually exclusive",
+			path:              "tls.issuer",
+			mutuallyExclusive: "tls.secret",
+			want: "if component.Spec.Tls.Issuer != \"\" && component.Spec.Tls.Secret != \"\" {\n" +
+				"errs = append(errs, field.Forbidden(field.NewPath(\"spec\", \"tls\", \"issuer\"), " +
+				"\"may not be set when spec.tls.secret is set\"))\n}\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newWebhookTestFields(t)
+			require.False(t, api.HasValidationRules())
+
+			api.setRules(tt.path, &tt.requiredIf, &tt.mutuallyExclusive)
+			require.NoError(t, api.validateRules(api))
+
+			assert.True(t, api.HasValidationRules())
+			assert.Equal(t, tt.want, api.GenerateValidation("component"))
+		})
+	}
+}
+
+func TestAPIFields_validateRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		requiredIf        string
+		mutuallyExclusive string
+		wantErr           error
+	}{
+		{
+			name:       "valid requiredIf",
+			requiredIf: "replicas=3",
+		},
+		{
+			name:              "valid mutuallyExclusive",
+			mutuallyExclusive: "image.name",
+		},
+		{
+			name:       "missing requiredIf reference",
+			requiredIf: "missing",
+			wantErr:    ErrInvalidFieldReference,
+		},
+		{
+			name:       "struct requiredIf reference",
+			requiredIf: "image",
+			wantErr:    ErrInvalidFieldReference,
+		},
+		{
+			name:              "missing mutuallyExclusive reference",
+			mutuallyExclusive: "image.tag",
+			wantErr:           ErrInvalidFieldReference,
+		},
+		{
+			name:       "invalid int value",
+			requiredIf: "replicas=many",
+			wantErr:    ErrInvalidRuleValue,
+		},
+		{
+			name:       "invalid bool value",
+			requiredIf: "tls.enabled=yes",
+			wantErr:    ErrInvalidRuleValue,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newWebhookTestFields(t)
+			api.setRules("mode", &tt.requiredIf, &tt.mutuallyExclusive)
+
+			err := api.validateRules(api)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
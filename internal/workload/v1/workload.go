@@ -5,6 +5,7 @@ package v1
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -27,17 +28,28 @@ const (
 	SampleWorkloadAPIVersion = "v1alpha1"
 )
 
+var ErrPausedFieldConflict = errors.New("the paused field enabled by spec.api.pausedField must be a bool")
+
+// pausedFieldName is the name of the field of the spec which pauses the reconciliation of the
+// workload, when enabled.
+const pausedFieldName = "paused"
+
 // WorkloadAPISpec contains fields shared by all workload specs.
 type WorkloadAPISpec struct {
-	Domain        string `json:"domain" yaml:"domain"`
-	Group         string `json:"group" yaml:"group"`
-	Version       string `json:"version" yaml:"version"`
-	Kind          string `json:"kind" yaml:"kind"`
-	ClusterScoped bool   `json:"clusterScoped" yaml:"clusterScoped"`
+	Domain        string   `json:"domain" yaml:"domain"`
+	Group         string   `json:"group" yaml:"group"`
+	Version       string   `json:"version" yaml:"version"`
+	Kind          string   `json:"kind" yaml:"kind"`
+	ClusterScoped bool     `json:"clusterScoped" yaml:"clusterScoped"`
+	Webhooks      bool     `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+	ShortNames    []string `json:"shortNames,omitempty" yaml:"shortNames,omitempty"`
+	Categories    []string `json:"categories,omitempty" yaml:"categories,omitempty"`
+	PausedField   bool     `json:"pausedField,omitempty" yaml:"pausedField,omitempty"`
 }
 
 // WorkloadShared contains fields shared by all workloads.
 type WorkloadShared struct {
+	APIVersion  string       `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty" validate:"omitempty"`
 	Name        string       `json:"name"  yaml:"name" validate:"required"`
 	Kind        WorkloadKind `json:"kind"  yaml:"kind" validate:"required"`
 	PackageName string       `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
@@ -46,8 +58,17 @@ type WorkloadShared struct {
 // WorkloadSpec contains information required to generate source code.
 type WorkloadSpec struct {
 	Resources              []*Resource              `json:"resources" yaml:"resources"`
+	Controller             *ControllerSpec          `json:"controller,omitempty" yaml:"controller,omitempty" validate:"omitempty"`
 	FieldMarkers           []*FieldMarker           `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	CollectionFieldMarkers []*CollectionFieldMarker `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	ValidationMarkers      []*ValidationMarker      `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	StatusFields           []*StatusField           `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	ReadyKinds             []string                 `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	ReadyConditions        []*ReadyCondition        `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	DeleteWaves            []*DeleteWave            `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	DriftRules             []*DriftRule             `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	RequeueRules           []*RequeueRule           `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	ControllerOptions      *ControllerOptions       `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	ForCollection          bool                     `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	Collection             *WorkloadCollection      `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	APISpecFields          *APIFields               `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
@@ -72,6 +93,13 @@ func (ws *WorkloadSpec) init() {
 	ws.OwnershipRules = &OwnershipRules{}
 	ws.RBACRules = &RBACRules{}
 	ws.SourceFiles = &[]SourceFile{}
+	ws.StatusFields = nil
+	ws.ReadyKinds = nil
+	ws.ReadyConditions = nil
+	ws.DeleteWaves = nil
+	ws.DriftRules = nil
+	ws.RequeueRules = nil
+	ws.ControllerOptions = nil
 }
 
 func (ws *WorkloadSpec) appendCollectionRef() {
@@ -136,6 +164,37 @@ func (ws *WorkloadSpec) appendCollectionRef() {
 	ws.APISpecFields.Children = append(ws.APISpecFields.Children, collectionField)
 }
 
+// appendPausedField appends the paused field, which pauses the reconciliation of the workload,
+// to the spec.  A field marker named paused is used as the paused field when it is a bool.
+func (ws *WorkloadSpec) appendPausedField() error {
+	for _, child := range ws.APISpecFields.Children {
+		if child.manifestName != pausedFieldName {
+			continue
+		}
+
+		if child.Type != FieldBool {
+			return fmt.Errorf("%w; the field marker %s is a %s", ErrPausedFieldConflict, pausedFieldName, child.Type)
+		}
+
+		return nil
+	}
+
+	ws.APISpecFields.Children = append(ws.APISpecFields.Children, &APIFields{
+		Name:         "Paused",
+		manifestName: pausedFieldName,
+		Type:         FieldBool,
+		Tags:         fmt.Sprintf("`json:%q`", pausedFieldName+",omitempty"),
+		Sample:       "#paused: false",
+		Markers: []string{
+			"+kubebuilder:validation:Optional",
+			"Pauses the reconciliation of the workload when true, e.g. during maintenance.",
+			"The workload is also paused by the paused annotation of its group.",
+		},
+	})
+
+	return nil
+}
+
 func NewSampleAPISpec() *WorkloadAPISpec {
 	return &WorkloadAPISpec{
 		Domain:        SampleWorkloadAPIDomain,
@@ -149,6 +208,14 @@ func NewSampleAPISpec() *WorkloadAPISpec {
 func (ws *WorkloadSpec) processManifests(markerTypes ...MarkerType) error {
 	ws.init()
 
+	if err := ws.processRequeuePolicies(); err != nil {
+		return err
+	}
+
+	if err := ws.processControllerOptions(); err != nil {
+		return err
+	}
+
 	for _, manifestFile := range ws.Resources {
 		err := ws.processMarkers(manifestFile, markerTypes...)
 		if err != nil {
@@ -186,11 +253,51 @@ func (ws *WorkloadSpec) processManifests(markerTypes ...MarkerType) error {
 			)
 
 			resource := ChildResource{
-				Name:       manifestObject.GetName(),
-				UniqueName: resourceUniqueName,
-				Group:      resourceGroup,
-				Version:    resourceVersion,
-				Kind:       manifestObject.GetKind(),
+				Name:          manifestObject.GetName(),
+				UniqueName:    resourceUniqueName,
+				Group:         resourceGroup,
+				Version:       resourceVersion,
+				Kind:          manifestObject.GetKind(),
+				ClusterScoped: isClusterScopedKind(manifestObject.GetKind()),
+			}
+
+			// a resource of which the namespace is set by a field may be placed in another namespace
+			// than the workload, where it is watched by its labels
+			if !resource.ClusterScoped && isFieldNamespace(manifestObject.GetNamespace()) {
+				resource.NamespaceField = true
+
+				ws.OwnershipRules.watchOtherNamespaces(manifestObject.GetAPIVersion(), manifestObject.GetKind())
+			}
+
+			statusFields, readyConditions := len(ws.StatusFields), len(ws.ReadyConditions)
+
+			// add the status fields which are copied from the resource
+			manifest, err = ws.processStatusMarkers(manifest, &resource)
+			if err != nil {
+				return formatProcessError(manifestFile.FileName, err)
+			}
+
+			// add the readiness checks of the resource
+			manifest, err = ws.processReadyMarkers(manifest, &resource)
+			if err != nil {
+				return formatProcessError(manifestFile.FileName, err)
+			}
+
+			// watch the status of the resource when the status or readiness of the workload is read from it
+			if len(ws.StatusFields) > statusFields || len(ws.ReadyConditions) > readyConditions || isReadyKind(&resource) {
+				ws.OwnershipRules.watchStatus(manifestObject.GetAPIVersion(), manifestObject.GetKind())
+			}
+
+			// add the resource to the wave in which it is deleted
+			manifest, err = ws.processDeleteMarkers(manifest, &resource)
+			if err != nil {
+				return formatProcessError(manifestFile.FileName, err)
+			}
+
+			// add the rule for drift of the resource from other field managers
+			manifest, err = ws.processDriftMarkers(manifest, &resource)
+			if err != nil {
+				return formatProcessError(manifestFile.FileName, err)
 			}
 
 			// generate the object source code
@@ -222,6 +329,23 @@ func (ws *WorkloadSpec) postProcessManifests() error {
 	// ensure no duplicate file names exist within the source files
 	ws.deduplicateFileNames()
 
+	// ensure the cross-field validation rules refer to existing fields
+	if err := ws.APISpecFields.validateRules(ws.APISpecFields); err != nil {
+		return err
+	}
+
+	// ensure each printer column of the api is displayed under a unique name
+	if err := ws.APISpecFields.validatePrinterColumns(); err != nil {
+		return err
+	}
+
+	// add the validation rules once all fields they may refer to exist
+	for _, vm := range ws.ValidationMarkers {
+		if err := ws.APISpecFields.addValidation(vm); err != nil {
+			return err
+		}
+	}
+
 	// process the child resource markers
 	for _, sourceFile := range *ws.SourceFiles {
 		for i := range sourceFile.Children {
@@ -303,6 +427,13 @@ func (ws *WorkloadSpec) processMarkerResults(markerResults []*inspect.YAMLResult
 				return err
 			}
 
+			ws.APISpecFields.setRules(r.Name, r.RequiredIf, r.MutuallyExclusive)
+			ws.APISpecFields.setPrinterColumn(r.Name, r.PrinterColumn, r.PrinterColumnName, r.PrinterColumnPriority)
+
+			if err := ws.APISpecFields.setEnum(r.Name, r.Enum); err != nil {
+				return err
+			}
+
 			ws.FieldMarkers = append(ws.FieldMarkers, &r)
 
 		case CollectionFieldMarker:
@@ -329,8 +460,18 @@ func (ws *WorkloadSpec) processMarkerResults(markerResults []*inspect.YAMLResult
 				return err
 			}
 
+			ws.APISpecFields.setRules(r.Name, r.RequiredIf, r.MutuallyExclusive)
+			ws.APISpecFields.setPrinterColumn(r.Name, r.PrinterColumn, r.PrinterColumnName, r.PrinterColumnPriority)
+
+			if err := ws.APISpecFields.setEnum(r.Name, r.Enum); err != nil {
+				return err
+			}
+
 			ws.CollectionFieldMarkers = append(ws.CollectionFieldMarkers, &r)
 
+		case ValidationMarker:
+			ws.ValidationMarkers = append(ws.ValidationMarkers, &r)
+
 		default:
 			continue
 		}
@@ -343,10 +484,11 @@ func (ws *WorkloadSpec) processMarkerResults(markerResults []*inspect.YAMLResult
 // we cannot guarantee that files exist in different directories and may have
 // naming collisions.
 func (ws *WorkloadSpec) deduplicateFileNames() {
-	// create a slice to track existing fileNames and preallocate an existing
-	// known conflict
-	fileNames := make([]string, len(*ws.SourceFiles)+1)
-	fileNames[len(fileNames)-1] = "resources.go"
+	// create a slice to track existing fileNames and preallocate the known
+	// conflicts with the files generated alongside the source files
+	reserved := []string{"resources.go", "status.go", "ready.go", "delete.go", "apply.go"}
+	fileNames := make([]string, len(*ws.SourceFiles), len(*ws.SourceFiles)+len(reserved))
+	fileNames = append(fileNames, reserved...)
 
 	// dereference the sourcefiles
 	sourceFiles := *ws.SourceFiles
@@ -0,0 +1,246 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+const (
+	checkAPIName        = "check-api"
+	checkAPIDescription = "Check an API for breaking changes against a previous version"
+
+	archiveDirectoryPermissions = 0755
+)
+
+var (
+	ErrBreakingAPIChanges      = errors.New("breaking api changes found")
+	ErrCheckAPIMissingPrevious = errors.New("one of --previous-workload-config or --previous-revision is required")
+	ErrCheckAPIBothPrevious    = errors.New("--previous-workload-config and --previous-revision cannot be used together")
+	ErrInvalidArchivePath      = errors.New("invalid path in git archive")
+)
+
+type checkAPIOptions struct {
+	workloadConfigPath         string
+	previousWorkloadConfigPath string
+	previousRevision           string
+	revision                   string
+}
+
+func NewCheckAPICmd() *cobra.Command {
+	options := &checkAPIOptions{}
+
+	cmd := &cobra.Command{
+		Use:   checkAPIName,
+		Short: checkAPIDescription,
+		Long: `Compare the API generated from a workload config with the API generated from a
+previous workload config, or from the same workload config at a previous git
+revision.  Removed fields, type changes, new required fields, changed defaults and
+narrowed enums are reported and each is classified as breaking or non-breaking
+according to the Kubernetes API conventions.  The command fails when any breaking
+change is found.`,
+		Example: `  # compare against a previous workload config
+  operator-builder check-api \
+      --workload-config .workloadConfig/workload.yaml \
+      --previous-workload-config /tmp/previous/workload.yaml
+
+  # compare against the workload config at the v0.1.0 tag
+  operator-builder check-api \
+      --workload-config .workloadConfig/workload.yaml \
+      --previous-revision v0.1.0`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.run(cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workloadConfigPath, "workload-config", "w", "", "path to the current workload config")
+	cmd.Flags().StringVar(&options.previousWorkloadConfigPath, "previous-workload-config", "",
+		"path to the previous workload config")
+	cmd.Flags().StringVar(&options.previousRevision, "previous-revision", "",
+		"git revision at which to read the previous workload config")
+	cmd.Flags().StringVar(&options.revision, "revision", "",
+		"git revision at which to read the current workload config (default: the working tree)")
+
+	if err := cmd.MarkFlagRequired("workload-config"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func (o *checkAPIOptions) run(out io.Writer) error {
+	switch {
+	case o.previousWorkloadConfigPath == "" && o.previousRevision == "":
+		return ErrCheckAPIMissingPrevious
+	case o.previousWorkloadConfigPath != "" && o.previousRevision != "":
+		return ErrCheckAPIBothPrevious
+	}
+
+	current, err := loadWorkload(o.workloadConfigPath, o.revision)
+	if err != nil {
+		return err
+	}
+
+	previousPath := o.previousWorkloadConfigPath
+	if previousPath == "" {
+		previousPath = o.workloadConfigPath
+	}
+
+	previous, err := loadWorkload(previousPath, o.previousRevision)
+	if err != nil {
+		return err
+	}
+
+	var breaking int
+
+	changes := workloadv1.CompareWorkloads(previous, current)
+
+	for _, change := range changes {
+		fmt.Fprintln(out, change)
+
+		if change.Type.Breaking() {
+			breaking++
+		}
+	}
+
+	fmt.Fprintf(out, "found %d breaking and %d non-breaking change(s)\n", breaking, len(changes)-breaking)
+
+	if breaking > 0 {
+		return fmt.Errorf("%w; %d change(s) require a new api version", ErrBreakingAPIChanges, breaking)
+	}
+
+	return nil
+}
+
+// loadWorkload processes a workload config from the working tree or, when a revision is
+// given, from the git repository containing the workload config at that revision.
+func loadWorkload(workloadConfigPath, revision string) (workloadv1.WorkloadAPIBuilder, error) {
+	if revision == "" {
+		workload, err := workloadv1.ProcessAPIConfig(workloadConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to process workload config %s, %w", workloadConfigPath, err)
+		}
+
+		return workload, nil
+	}
+
+	configPath, err := filepath.Abs(workloadConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine path of workload config %s, %w", workloadConfigPath, err)
+	}
+
+	topLevel, err := git(filepath.Dir(configPath), "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	topLevel = strings.TrimSpace(topLevel)
+
+	relativePath, err := filepath.Rel(topLevel, configPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine path of workload config %s, %w", workloadConfigPath, err)
+	}
+
+	dir, err := os.MkdirTemp("", "operator-builder-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary directory, %w", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	archive, err := git(topLevel, "archive", "--format=tar", revision)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := extractArchive(strings.NewReader(archive), dir); err != nil {
+		return nil, fmt.Errorf("unable to extract revision %s, %w", revision, err)
+	}
+
+	workload, err := workloadv1.ProcessAPIConfig(filepath.Join(dir, relativePath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to process workload config %s at revision %s, %w", workloadConfigPath, revision, err)
+	}
+
+	return workload, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("unable to run git %s, %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+func extractArchive(archive io.Reader, dir string) error {
+	reader := tar.NewReader(archive)
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to read archive, %w", err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("%w, %s", ErrInvalidArchivePath, header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, archiveDirectoryPermissions); err != nil {
+				return fmt.Errorf("unable to create directory %s, %w", path, err)
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(reader, path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeArchiveFile(reader io.Reader, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), archiveDirectoryPermissions); err != nil {
+		return fmt.Errorf("unable to create directory for %s, %w", path, err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create file %s, %w", path, err)
+	}
+
+	defer file.Close()
+
+	//nolint:gosec // the archive is produced by git from the local repository
+	if _, err := io.Copy(file, reader); err != nil {
+		return fmt.Errorf("unable to write file %s, %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1"
+)
+
+// stopOnDryRun ends the create api command successfully once the workload plugin has
+// previewed a dry run, skipping the scaffolding and saving of the project by the
+// remaining hooks of the plugin chain.
+func stopOnDryRun(root *cobra.Command) {
+	cmd, _, err := root.Find([]string{"create", "api"})
+	if err != nil || cmd.PreRunE == nil {
+		return
+	}
+
+	preRunE := cmd.PreRunE
+
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		err := preRunE(cmd, args)
+		if !errors.Is(err, workloadv1.ErrDryRun) {
+			return err
+		}
+
+		cmd.RunE = func(*cobra.Command, []string) error { return nil }
+		cmd.PostRunE = nil
+
+		return nil
+	}
+}
@@ -32,6 +32,8 @@ func NewKubebuilderCLI() (*kbcli.CLI, error) {
 		workloadv1.Plugin{},
 	)
 
+	updateCmd := NewUpdateCmd()
+
 	c, err := kbcli.New(
 		kbcli.WithCommandName("operator-builder"),
 		kbcli.WithVersion(version),
@@ -46,13 +48,20 @@ func NewKubebuilderCLI() (*kbcli.CLI, error) {
 		kbcli.WithDefaultPlugins(cfgv2.Version, golangv2.Plugin{}),
 		kbcli.WithDefaultPlugins(cfgv3.Version, gov3Bundle),
 		kbcli.WithDefaultProjectVersion(cfgv3.Version),
-		kbcli.WithExtraCommands(NewUpdateCmd()),
+		kbcli.WithExtraCommands(updateCmd),
 		kbcli.WithExtraCommands(NewInitConfigCmd()),
+		kbcli.WithExtraCommands(NewMigrateConfigCmd()),
+		kbcli.WithExtraCommands(NewSuggestMarkersCmd()),
+		kbcli.WithExtraCommands(NewCheckAPICmd()),
 		kbcli.WithCompletion(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create kcli command, %w", err)
 	}
 
+	// the cli does not expose its root command, so it is reached through one of the
+	// extra commands which have been added to it
+	stopOnDryRun(updateCmd.Root())
+
 	return c, nil
 }
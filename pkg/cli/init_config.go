@@ -43,6 +43,7 @@ type initConfigSubCommand struct {
 	subCommandName        string
 	subCommandDescription string
 	options               *workloadv1.InitConfigOptions
+	inferOptions          *inferConfigOptions
 }
 
 func NewInitConfigCmd() *cobra.Command {
@@ -72,6 +73,7 @@ func newInitConfigSubCommand(subCmdName, subCmdDescription string) *initConfigSu
 		subCommandName:        subCmdName,
 		subCommandDescription: subCmdDescription,
 		options:               &workloadv1.InitConfigOptions{},
+		inferOptions:          &inferConfigOptions{},
 	}
 }
 
@@ -106,6 +108,19 @@ func (i *initConfigSubCommand) addInitSubCommand(parentCommand *cobra.Command) e
 	}
 
 	subCommand.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := i.validateInferFlags(cmd); err != nil {
+			return fmt.Errorf("%w; %s", err, returnErr)
+		}
+
+		// infer the workload config from a directory of manifests if requested
+		if i.inferOptions.manifestsPath != "" {
+			if err := i.inferConfig(cmd); err != nil {
+				return fmt.Errorf("%w; %s", err, returnErr)
+			}
+
+			return nil
+		}
+
 		if err := workloadv1.WriteConfig(i.options); err != nil {
 			return fmt.Errorf("%w; %s", err, returnErr)
 		}
@@ -115,7 +130,11 @@ func (i *initConfigSubCommand) addInitSubCommand(parentCommand *cobra.Command) e
 
 	parentCommand.AddCommand(subCommand)
 
-	return i.addCommonFlags(subCommand)
+	if err := i.addCommonFlags(subCommand); err != nil {
+		return err
+	}
+
+	return i.addInferFlags(subCommand)
 }
 
 func (i *initConfigSubCommand) addCommonFlags(cmd *cobra.Command) error {
@@ -0,0 +1,256 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+var (
+	ErrInferCollectionStdout = errors.New("a file path is required when inferring a collection as multiple configs are written")
+	ErrInvalidPromptInput    = errors.New("invalid input")
+	ErrInferFlagsManifests   = errors.New("flags which infer a workload config require --manifests")
+)
+
+// inferConfigOptions contains the options used to infer a workload configuration
+// from a directory of manifests rather than writing a sample configuration.
+type inferConfigOptions struct {
+	manifestsPath string
+	groupBy       string
+	interactive   bool
+
+	name          string
+	domain        string
+	group         string
+	version       string
+	kind          string
+	clusterScoped bool
+}
+
+func (i *initConfigSubCommand) addInferFlags(cmd *cobra.Command) error {
+	cmd.Flags().StringVarP(&i.inferOptions.manifestsPath, "manifests", "m", "",
+		"directory of manifests used to infer the workload config instead of writing a sample")
+	cmd.Flags().BoolVarP(&i.inferOptions.interactive, "interactive", "i", false,
+		"prompt to confirm or change each inferred value (requires --manifests)")
+
+	cmd.Flags().StringVar(&i.inferOptions.name, "name", "", "workload name (default: inferred from the manifests directory)")
+	cmd.Flags().StringVar(&i.inferOptions.domain, "domain", "", "api domain for the inferred workload config")
+	cmd.Flags().StringVar(&i.inferOptions.group, "group", "", "api group for the inferred workload config")
+	cmd.Flags().StringVar(&i.inferOptions.version, "version", "", "api version for the inferred workload config")
+	cmd.Flags().StringVar(&i.inferOptions.kind, "kind", "", "api kind for the inferred workload config")
+	cmd.Flags().BoolVar(&i.inferOptions.clusterScoped, "cluster-scoped", false,
+		"whether the inferred workload is cluster scoped (default: inferred from the manifests)")
+
+	if i.subCommandName == collectionSubCommandName {
+		cmd.Flags().StringVar(&i.inferOptions.groupBy, "group-by", workloadv1.GroupByDirectory,
+			fmt.Sprintf("how manifests are grouped into components (%s or %s)",
+				workloadv1.GroupByDirectory, workloadv1.GroupByNamespace))
+	}
+
+	return nil
+}
+
+// inferFlagNames returns the names of the flags which are only used when inferring a
+// workload config from a directory of manifests.
+func (i *initConfigSubCommand) inferFlagNames() []string {
+	names := []string{"interactive", "name", "domain", "group", "version", "kind", "cluster-scoped"}
+
+	if i.subCommandName == collectionSubCommandName {
+		names = append(names, "group-by")
+	}
+
+	return names
+}
+
+// validateInferFlags returns an error if a flag which is only used when inferring a
+// workload config was set without a directory of manifests.
+func (i *initConfigSubCommand) validateInferFlags(cmd *cobra.Command) error {
+	if i.inferOptions.manifestsPath != "" {
+		return nil
+	}
+
+	for _, name := range i.inferFlagNames() {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("%w; --%s was set", ErrInferFlagsManifests, name)
+		}
+	}
+
+	return nil
+}
+
+// inferConfig proposes a workload config from a directory of manifests, applies any
+// requested overrides and writes the resulting workload configs.
+func (i *initConfigSubCommand) inferConfig(cmd *cobra.Command) error {
+	isCollection := i.subCommandName == collectionSubCommandName
+
+	if isCollection && i.options.Path == "-" {
+		return ErrInferCollectionStdout
+	}
+
+	proposal, err := workloadv1.ProposeConfig(&workloadv1.ProposeConfigOptions{
+		ManifestsPath: i.inferOptions.manifestsPath,
+		ConfigPath:    i.options.Path,
+		GroupBy:       i.inferOptions.groupBy,
+		Collection:    isCollection,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to infer workload config from %s, %w", i.inferOptions.manifestsPath, err)
+	}
+
+	proposal.SetName(i.inferOptions.name)
+	proposal.SetAPI(i.inferOptions.domain, i.inferOptions.group, i.inferOptions.version, i.inferOptions.kind)
+
+	if cmd.Flags().Changed("cluster-scoped") {
+		proposal.API.ClusterScoped = i.inferOptions.clusterScoped
+	}
+
+	if i.inferOptions.interactive {
+		p := &prompter{reader: bufio.NewReader(cmd.InOrStdin()), writer: cmd.ErrOrStderr()}
+
+		if err := p.promptProposal(proposal); err != nil {
+			return err
+		}
+	}
+
+	switch i.subCommandName {
+	case collectionSubCommandName:
+		return i.writeCollection(proposal)
+	case componentSubCommandName:
+		i.options.WorkloadConfig = proposal.Component()
+	default:
+		i.options.WorkloadConfig = proposal.Standalone()
+	}
+
+	return workloadv1.WriteConfig(i.options)
+}
+
+// writeCollection writes the config for each proposed component alongside the
+// collection config followed by the collection config itself.
+func (i *initConfigSubCommand) writeCollection(proposal *workloadv1.ConfigProposal) error {
+	for _, component := range proposal.Components {
+		if err := workloadv1.WriteConfig(&workloadv1.InitConfigOptions{
+			Path:           filepath.Join(filepath.Dir(i.options.Path), component.ComponentFile()),
+			Force:          i.options.Force,
+			WorkloadConfig: component.Component(),
+		}); err != nil {
+			return fmt.Errorf("%w; unable to write component config %s", err, component.Name)
+		}
+	}
+
+	i.options.WorkloadConfig = proposal.Collection()
+
+	return workloadv1.WriteConfig(i.options)
+}
+
+// prompter prompts for confirmation of, or changes to, inferred values.
+type prompter struct {
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+func (p *prompter) promptProposal(proposal *workloadv1.ConfigProposal) error {
+	fmt.Fprintf(p.writer, "Found %d manifest file(s) for %s\n", proposal.ManifestFiles, proposal.Name)
+
+	var err error
+
+	for _, prompt := range []struct {
+		label string
+		value *string
+	}{
+		{label: "Workload name", value: &proposal.Name},
+		{label: "API domain", value: &proposal.API.Domain},
+		{label: "API group", value: &proposal.API.Group},
+		{label: "API version", value: &proposal.API.Version},
+		{label: "API kind", value: &proposal.API.Kind},
+	} {
+		if *prompt.value, err = p.promptString(prompt.label, *prompt.value); err != nil {
+			return err
+		}
+	}
+
+	if proposal.API.ClusterScoped, err = p.promptBool("Cluster scoped", proposal.API.ClusterScoped); err != nil {
+		return err
+	}
+
+	for _, component := range proposal.Components {
+		fmt.Fprintf(p.writer, "\nComponent %s with resources:\n  %s\n", component.Name, strings.Join(component.Resources, "\n  "))
+
+		// components share the api domain, group and version of the collection
+		component.API.Domain = proposal.API.Domain
+		component.API.Group = proposal.API.Group
+		component.API.Version = proposal.API.Version
+
+		if component.Name, err = p.promptString("Component name", component.Name); err != nil {
+			return err
+		}
+
+		if component.API.Kind, err = p.promptString("Component API kind", component.API.Kind); err != nil {
+			return err
+		}
+
+		if component.API.ClusterScoped, err = p.promptBool("Component cluster scoped", component.API.ClusterScoped); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promptString prompts for a string value, returning the proposed value when no input
+// is given.
+func (p *prompter) promptString(label, proposed string) (string, error) {
+	value, _, err := p.readValue(label, proposed)
+
+	return value, err
+}
+
+// promptBool prompts for a boolean value, returning the proposed value when no input
+// is given.  An invalid value is prompted for again until the input is exhausted.
+func (p *prompter) promptBool(label string, proposed bool) (bool, error) {
+	for {
+		input, eof, err := p.readValue(label, strconv.FormatBool(proposed))
+		if err != nil {
+			return false, err
+		}
+
+		value, err := strconv.ParseBool(input)
+		if err == nil {
+			return value, nil
+		}
+
+		if eof {
+			return false, fmt.Errorf("%w %q for %s, expected true or false", ErrInvalidPromptInput, input, label)
+		}
+
+		fmt.Fprintf(p.writer, "invalid value %q, expected true or false\n", input)
+	}
+}
+
+// readValue prompts for a value, returning the proposed value when no input is given
+// and whether the end of the input was reached.
+func (p *prompter) readValue(label, proposed string) (string, bool, error) {
+	fmt.Fprintf(p.writer, "%s [%s]: ", label, proposed)
+
+	input, err := p.reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, fmt.Errorf("unable to read input for %s, %w", label, err)
+	}
+
+	value := strings.TrimSpace(input)
+	if value == "" {
+		value = proposed
+	}
+
+	return value, errors.Is(err, io.EOF), nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+const (
+	migrateConfigName        = "migrate-config"
+	migrateConfigDescription = "Migrate a workload configuration to the latest config version"
+)
+
+func NewMigrateConfigCmd() *cobra.Command {
+	var workloadConfigPath string
+
+	cmd := &cobra.Command{
+		Use:   migrateConfigName,
+		Short: migrateConfigDescription,
+		Long: `Migrate a workload configuration, and any component configurations referenced
+by a collection, to the latest workload config version.  Files are rewritten in
+place and comments within the existing configuration are preserved.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrated, err := workloadv1.MigrateConfig(workloadConfigPath)
+			if err != nil {
+				return fmt.Errorf("unable to migrate workload config %s, %w", workloadConfigPath, err)
+			}
+
+			if len(migrated) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "workload config already at version %s\n", workloadv1.WorkloadConfigLatestVersion)
+
+				return nil
+			}
+
+			for _, path := range migrated {
+				fmt.Fprintf(cmd.OutOrStdout(), "migrated %s to version %s\n", path, workloadv1.WorkloadConfigLatestVersion)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&workloadConfigPath, "workload-config", "w", "", "path to workload config file")
+
+	if err := cmd.MarkFlagRequired("workload-config"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/workload/v1"
+)
+
+const (
+	suggestMarkersName        = "suggest-markers"
+	suggestMarkersDescription = "Suggest field markers for a directory of manifests"
+)
+
+func NewSuggestMarkersCmd() *cobra.Command {
+	options := &workloadv1.SuggestMarkersOptions{}
+
+	cmd := &cobra.Command{
+		Use:   suggestMarkersName,
+		Short: suggestMarkersDescription,
+		Long: `Analyze a directory of manifests and suggest field markers for values which are
+commonly parameterized, such as image tags, replicas, resource requests and
+limits, namespaces, hostnames and storage sizes.  Annotated copies of the
+manifests are written to the output directory and the original manifests are
+left untouched.  Values which already have a marker are skipped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			suggestions, err := workloadv1.SuggestMarkers(options)
+			if err != nil {
+				return fmt.Errorf("unable to suggest markers for %s, %w", options.ManifestsPath, err)
+			}
+
+			for _, suggestion := range suggestions {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s %s -> %s\n",
+					suggestion.File, suggestion.Object, suggestion.Path, suggestion.Field)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "suggested %d field marker(s), annotated manifests written to %s\n",
+				len(suggestions), options.OutputPath)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.ManifestsPath, "manifests", "m", "", "directory of manifests to analyze")
+	cmd.Flags().StringVarP(&options.OutputPath, "output", "o", "", "directory to write the annotated manifests to")
+	cmd.Flags().BoolVarP(&options.Force, "force", "f", false, "overwrite existing files in the output directory")
+
+	for _, flag := range []string{"manifests", "output"} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			panic(err)
+		}
+	}
+
+	return cmd
+}
@@ -16,6 +16,7 @@ func NewUpdateCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		NewUpdateLicenseCmd(),
+		NewUpdateAPICmd(),
 	)
 
 	return cmd
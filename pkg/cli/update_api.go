@@ -0,0 +1,45 @@
+// Copyright 2021 VMware, Inc.
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+
+	workloadv1 "github.com/vmware-tanzu-labs/operator-builder/internal/plugins/workload/v1"
+)
+
+func NewUpdateAPICmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Update existing APIs from changed manifests",
+		Long: `Update the existing APIs of a project after the workload config or its manifests
+have changed.  The workload config recorded in the PROJECT file is used to regenerate
+the child resource definitions, spec types, webhooks, samples and RBAC markers for every
+workload.  Controllers and other files owned by the user are left untouched.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var preview io.Writer
+			if dryRun {
+				preview = cmd.OutOrStdout()
+			}
+
+			if err := workloadv1.UpdateAPI(machinery.Filesystem{FS: afero.NewOsFs()}, preview); err != nil {
+				return fmt.Errorf("unable to update api, %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"print the files that would be written, along with a diff against the project, without writing them")
+
+	return cmd
+}